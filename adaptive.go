@@ -0,0 +1,124 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// adaptiveRules tell when the level of the interview changes by itself: up after upStreak
+// OK marks in a row and down after downStreak Wrong marks in a row.
+type adaptiveRules struct {
+	enabled    bool
+	upStreak   int
+	downStreak int
+}
+
+func (r adaptiveRules) String() string {
+	state := "off"
+	if r.enabled {
+		state = "on"
+	}
+	return fmt.Sprintf("%s, up after %d OK in a row, down after %d wrong in a row", state, r.upStreak, r.downStreak)
+}
+
+var defaultAdaptiveRules = adaptiveRules{upStreak: 3, downStreak: 2}
+
+// adaptiveRulesFrom reads the rules of the settings file, e.g.
+//
+//	adaptive:
+//	  enabled: true
+//	  up_streak: 3
+//	  down_streak: 2
+func adaptiveRulesFrom(v *viper.Viper) adaptiveRules {
+	rules := adaptiveRules{
+		enabled:    v.GetBool("adaptive.enabled"),
+		upStreak:   v.GetInt("adaptive.up_streak"),
+		downStreak: v.GetInt("adaptive.down_streak"),
+	}
+	if rules.upStreak <= 0 {
+		rules.upStreak = defaultAdaptiveRules.upStreak
+	}
+	if rules.downStreak <= 0 {
+		rules.downStreak = defaultAdaptiveRules.downStreak
+	}
+	return rules
+}
+
+// setAdaptive turns the adaptive mode on or off, two numbers turn it on with new streaks.
+func setAdaptive(options []string, config *Config) error {
+	rules := config.adaptive
+	switch {
+	case len(options) == 1 && strings.ToLower(options[0]) == "on":
+		rules.enabled = true
+	case len(options) == 1 && strings.ToLower(options[0]) == "off":
+		rules.enabled = false
+	case len(options) == 2:
+		up, errUp := strconv.Atoi(options[0])
+		down, errDown := strconv.Atoi(options[1])
+		if errUp != nil || errDown != nil || up <= 0 || down <= 0 {
+			return newCommandError("the streaks must be numbers greater than zero, e.g. adaptive 3 2")
+		}
+		rules = adaptiveRules{enabled: true, upStreak: up, downStreak: down}
+	default:
+		return newCommandError("use adaptive on, adaptive off or adaptive <up-streak> <down-streak>")
+	}
+	config.adaptive = rules
+	config.okStreak, config.wrongStreak = 0, 0
+	return nil
+}
+
+// adaptLevel keeps the streaks of the interview and moves the level when a streak is
+// reached, it returns the change made, 0 when the level stays the same.
+func adaptLevel(result Result, config *Config) int {
+	if !config.adaptive.enabled {
+		return 0
+	}
+	switch result {
+	case OK:
+		config.okStreak++
+		config.wrongStreak = 0
+	case Wrong:
+		config.wrongStreak++
+		config.okStreak = 0
+	default:
+		config.okStreak, config.wrongStreak = 0, 0
+		return 0
+	}
+
+	switch {
+	case config.okStreak >= config.adaptive.upStreak && config.levelIndex+1 < len(config.levels):
+		config.levelIndex++
+		config.okStreak = 0
+		return 1
+	case config.wrongStreak >= config.adaptive.downStreak && config.levelIndex > 0:
+		config.levelIndex--
+		config.wrongStreak = 0
+		return -1
+	}
+	return 0
+}
+
+// announceLevelChange tells the interviewer about an automatic level change and saves it
+// in the history of the interview.
+func announceLevelChange(change int, config *Config, db *sql.DB) error {
+	if change == 0 {
+		return nil
+	}
+	var detail string
+	if change > 0 {
+		detail = fmt.Sprintf("%d OK in a row, level raised to %s", config.adaptive.upStreak, config.levels[config.levelIndex])
+	} else {
+		detail = fmt.Sprintf("%d wrong in a row, level lowered to %s", config.adaptive.downStreak, config.levels[config.levelIndex])
+	}
+	printWithColorln("Adaptive: "+detail, cyan, config)
+	id, err := saveInterviewEvent(config.intervieweeID, levelChangeEvent, detail, db)
+	if err != nil {
+		return err
+	}
+	config.history.events = append(config.history.events, savedEvent{id: id, event: levelChangeEvent, detail: detail})
+	return nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func Test_adaptLevel(t *testing.T) {
+	type test struct {
+		marks      []Result
+		startLevel int
+		wantLevel  int
+		wantChange int
+	}
+
+	tests := []test{
+		{marks: []Result{OK, OK}, startLevel: 0, wantLevel: 0, wantChange: 0},
+		{marks: []Result{OK, OK, OK}, startLevel: 0, wantLevel: 1, wantChange: 1},
+		{marks: []Result{OK, OK, Wrong, OK}, startLevel: 0, wantLevel: 0, wantChange: 0},
+		{marks: []Result{OK, OK, Neutral, OK}, startLevel: 0, wantLevel: 0, wantChange: 0},
+		{marks: []Result{OK, OK, OK}, startLevel: 2, wantLevel: 2, wantChange: 0},
+		{marks: []Result{Wrong, Wrong}, startLevel: 1, wantLevel: 0, wantChange: -1},
+		{marks: []Result{Wrong, Wrong}, startLevel: 0, wantLevel: 0, wantChange: 0},
+		{marks: []Result{OK, OK, OK, OK, OK, OK}, startLevel: 0, wantLevel: 2, wantChange: 1},
+	}
+
+	for _, tt := range tests {
+		config := NewConfig()
+		config.adaptive.enabled = true
+		config.levelIndex = tt.startLevel
+		change := 0
+		for _, mark := range tt.marks {
+			change = adaptLevel(mark, &config)
+		}
+		if config.levelIndex != tt.wantLevel || change != tt.wantChange {
+			t.Errorf("marks=%v: got level=[%d] change=[%d], want level=[%d] change=[%d]",
+				tt.marks, config.levelIndex, change, tt.wantLevel, tt.wantChange)
+		}
+	}
+
+	config := NewConfig()
+	for i := 0; i < 5; i++ {
+		if change := adaptLevel(OK, &config); change != 0 {
+			t.Errorf("adaptive mode is off, got change=[%d]", change)
+		}
+	}
+}
+
+func Test_setAdaptive(t *testing.T) {
+	config := NewConfig()
+
+	if err := setAdaptive([]string{"on"}, &config); err != nil || !config.adaptive.enabled {
+		t.Errorf("got rules=[%s] error=[%v]", config.adaptive, err)
+	}
+	if err := setAdaptive([]string{"4", "1"}, &config); err != nil ||
+		config.adaptive != (adaptiveRules{enabled: true, upStreak: 4, downStreak: 1}) {
+		t.Errorf("got rules=[%s] error=[%v]", config.adaptive, err)
+	}
+	if err := setAdaptive([]string{"off"}, &config); err != nil || config.adaptive.enabled || config.adaptive.upStreak != 4 {
+		t.Errorf("got rules=[%s] error=[%v]", config.adaptive, err)
+	}
+
+	for _, options := range [][]string{{"maybe"}, {"3", "x"}, {"0", "2"}} {
+		if err := setAdaptive(options, &config); err == nil {
+			t.Errorf("options=%v: want error", options)
+		}
+	}
+}
+
+func Test_adaptiveRulesFrom(t *testing.T) {
+	v := viper.New()
+	if got := adaptiveRulesFrom(v); got != defaultAdaptiveRules {
+		t.Errorf("got=[%s], want=[%s]", got, defaultAdaptiveRules)
+	}
+
+	v.SetConfigType("yaml")
+	settings := `
+adaptive:
+  enabled: true
+  up_streak: 5
+`
+	if err := v.ReadConfig(strings.NewReader(settings)); err != nil {
+		t.Fatal(err)
+	}
+	want := adaptiveRules{enabled: true, upStreak: 5, downStreak: defaultAdaptiveRules.downStreak}
+	if got := adaptiveRulesFrom(v); got != want {
+		t.Errorf("got=[%s], want=[%s]", got, want)
+	}
+}
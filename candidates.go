@@ -0,0 +1,354 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode/utf8"
+)
+
+// candidatesPerPage is how many candidates li prints at once.
+const candidatesPerPage = 20
+
+// candidateFields are the fields ec can change, in the order they are asked.
+var candidateFields = []string{"name", "email", "position", "seniority", "source", "notes"}
+
+// candidateFieldLengths are the sizes of the candidate columns.
+var candidateFieldLengths = map[string]int{
+	"name": 100, "email": 255, "position": 100, "seniority": 45, "source": 100, "notes": 1000,
+}
+
+// candidateFilter selects the candidates listed by li and search, the deleted ones are
+// always left out.
+type candidateFilter struct {
+	name     string
+	text     string
+	position string
+	from     time.Time
+	to       time.Time
+	page     int
+}
+
+func (f candidateFilter) where() (string, []interface{}) {
+	clauses := []string{"deleted = 0"}
+	args := make([]interface{}, 0)
+	if len(f.name) > 0 {
+		clauses = append(clauses, "name like ?")
+		args = append(args, "%"+f.name+"%")
+	}
+	if len(f.text) > 0 {
+		clauses = append(clauses, "(name like ? or email like ? or position like ? or source like ? or notes like ?)")
+		for i := 0; i < 5; i++ {
+			args = append(args, "%"+f.text+"%")
+		}
+	}
+	if len(f.position) > 0 {
+		clauses = append(clauses, "position like ?")
+		args = append(args, "%"+f.position+"%")
+	}
+	if !f.from.IsZero() {
+		clauses = append(clauses, "date >= ?")
+		args = append(args, f.from)
+	}
+	if !f.to.IsZero() {
+		// The whole day of --to is included.
+		clauses = append(clauses, "date < ?")
+		args = append(args, f.to.AddDate(0, 0, 1))
+	}
+	return strings.Join(clauses, " and "), args
+}
+
+// parseCandidateFilter reads the arguments of li: the words that are not options are
+// part of the name, e.g. li leo --from 2020-06-01 --position backend --page 2
+func parseCandidateFilter(args []string) (candidateFilter, error) {
+	filter := candidateFilter{page: 1}
+	name := make([]string, 0)
+	for i := 0; i < len(args); i++ {
+		option := args[i]
+		if !strings.HasPrefix(option, "--") {
+			name = append(name, option)
+			continue
+		}
+		if i+1 == len(args) {
+			return filter, newCommandError("%s needs a value", option)
+		}
+		i++
+		value := args[i]
+		var err error
+		switch option {
+		case "--from":
+			filter.from, err = time.Parse("2006-01-02", value)
+		case "--to":
+			filter.to, err = time.Parse("2006-01-02", value)
+		case "--position":
+			filter.position = value
+		case "--page":
+			filter.page, err = strconv.Atoi(value)
+			if err == nil && filter.page < 1 {
+				err = fmt.Errorf("the first page is 1")
+			}
+		default:
+			return filter, newCommandError("unknown option '%s', the options are --from, --to, --position and --page", option)
+		}
+		if err != nil {
+			return filter, newCommandError("invalid %s '%s', dates are written as YYYY-MM-DD", option, value)
+		}
+	}
+	filter.name = strings.Join(name, " ")
+	return filter, nil
+}
+
+func listCandidates(args []string, config *Config, db *sql.DB) error {
+	filter, err := parseCandidateFilter(args)
+	if err != nil {
+		return err
+	}
+	candidates, total, err := findCandidates(filter, db)
+	if err != nil {
+		return err
+	}
+	if total == 0 {
+		printWithColorln("No candidates found.", yellow, config)
+		return nil
+	}
+
+	printCandidates(candidates)
+	pages := (total + candidatesPerPage - 1) / candidatesPerPage
+	fmt.Println()
+	printWithColorf(config, "Page %d of %d, %d candidates.", gray, filter.page, pages, total)
+	if filter.page < pages {
+		printWithColorf(config, " Use --page %d for the next one.", gray, filter.page+1)
+	}
+	fmt.Println()
+	return nil
+}
+
+func searchCandidates(args []string, config *Config, db *sql.DB) error {
+	candidates, _, err := findCandidates(candidateFilter{text: strings.Join(args, " ")}, db)
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		printWithColorln("No candidates found.", yellow, config)
+		return nil
+	}
+	printCandidates(candidates)
+	return nil
+}
+
+func printCandidates(candidates []CandidateView) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tNAME\tDATE\tPOSITION\tSENIORITY\tEMAIL")
+	for _, c := range candidates {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", c.ID, c.Name, c.Date, c.Position, c.Seniority, c.Email)
+	}
+	w.Flush()
+}
+
+func parseCandidateID(arg string) (int, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+	if err != nil {
+		return 0, newCommandError("'%s' is not a candidate #", arg)
+	}
+	return id, nil
+}
+
+// editCandidate changes the profile of a candidate, either with inline field/value pairs
+// (ec 7 position backend email leo@example.com) or asking for each field.
+func editCandidate(args []string, config *Config, db *sql.DB) error {
+	id, err := parseCandidateID(args[0])
+	if err != nil {
+		return err
+	}
+	candidate, err := getCandidate(id, db)
+	if err != nil {
+		return err
+	}
+
+	var changes map[string]string
+	switch {
+	case len(args) > 1:
+		if len(args)%2 == 0 {
+			return newCommandError("the fields go in pairs, e.g. ec %d position backend", id)
+		}
+		changes = make(map[string]string)
+		for i := 1; i < len(args); i += 2 {
+			changes[strings.ToLower(args[i])] = args[i+1]
+		}
+	case !config.input.interactive():
+		return newCommandError("pass the fields inline, e.g. ec %d position backend", id)
+	default:
+		current := map[string]string{"name": candidate.Name, "email": candidate.Email, "position": candidate.Position,
+			"seniority": candidate.Seniority, "source": candidate.Source, "notes": candidate.Notes}
+		fmt.Println("Press enter to keep the current value, - clears it.")
+		if changes, err = readCandidateFields(candidateFields, current, config); err != nil {
+			return err
+		}
+	}
+
+	if err := applyCandidateChanges(&candidate, changes); err != nil {
+		return err
+	}
+	if err := updateCandidate(&candidate, db); err != nil {
+		return err
+	}
+	if config.hasStarted && config.intervieweeID == candidate.ID {
+		config.interview.Interviewee = candidate.Name
+	}
+	printWithColorln(fmt.Sprintf("Candidate #%d saved: %s", candidate.ID, candidate.Name), green, config)
+	return nil
+}
+
+// readCandidateFields asks for each field, the ones left empty are not changed.
+func readCandidateFields(fields []string, current map[string]string, config *Config) (map[string]string, error) {
+	changes := make(map[string]string)
+	for _, field := range fields {
+		if value, ok := current[field]; ok && len(value) > 0 {
+			printWithColorf(config, "%s: %s\n", gray, field, value)
+		}
+		value, err := config.input.readLine(strings.ToUpper(field[:1]) + field[1:] + "? ")
+		if err != nil {
+			return changes, err
+		}
+		if value = strings.TrimSpace(value); len(value) > 0 {
+			changes[field] = value
+		}
+	}
+	return changes, nil
+}
+
+func applyCandidateChanges(candidate *CandidateView, changes map[string]string) error {
+	for field, value := range changes {
+		value = strings.TrimSpace(value)
+		if value == "-" {
+			value = ""
+		}
+		max, ok := candidateFieldLengths[field]
+		if !ok {
+			return newCommandError("unknown field '%s', the fields are: %s", field, strings.Join(candidateFields, ", "))
+		}
+		if utf8.RuneCountInString(value) > max {
+			return newCommandError("the %s can't be longer than %d characters", field, max)
+		}
+		switch field {
+		case "name":
+			if len(value) == 0 {
+				return newCommandError("the name can't be empty")
+			}
+			candidate.Name = value
+		case "email":
+			if len(value) > 0 && (!strings.Contains(value, "@") || strings.ContainsAny(value, " \t")) {
+				return newCommandError("'%s' is not an email", value)
+			}
+			candidate.Email = value
+		case "position":
+			candidate.Position = value
+		case "seniority":
+			candidate.Seniority = value
+		case "source":
+			candidate.Source = value
+		case "notes":
+			candidate.Notes = value
+		}
+	}
+	return nil
+}
+
+// readCandidateProfile asks for the optional profile of the candidate when an interview
+// starts, nothing is asked when the name was given inline.
+func readCandidateProfile(config *Config) (map[string]string, error) {
+	if !config.input.interactive() {
+		return map[string]string{}, nil
+	}
+	fmt.Println("The profile is optional, press enter to skip a field.")
+	return readCandidateFields(candidateFields[1:], map[string]string{}, config)
+}
+
+// notRunning refuses to change the candidate of the running interview.
+func notRunning(candidateID int, config *Config) error {
+	if config.hasStarted && config.intervieweeID == candidateID {
+		return newCommandError("#%d is the candidate of the running interview, finish it first", candidateID)
+	}
+	return nil
+}
+
+// deleteCandidate hides a candidate from li, search and ei, the interview is kept in
+// the database.
+func deleteCandidate(args []string, config *Config, db *sql.DB) error {
+	args, yes := assumeYes(args)
+	if len(args) != 1 {
+		return newCommandError("usage: dc <candidate-id> [--yes]")
+	}
+	id, err := parseCandidateID(args[0])
+	if err != nil {
+		return err
+	}
+	if err := notRunning(id, config); err != nil {
+		return err
+	}
+	candidate, err := getCandidate(id, db)
+	if err != nil {
+		return err
+	}
+	if candidate.Deleted {
+		return newCommandError("candidate #%d is already deleted", id)
+	}
+	confirmed, err := confirm(fmt.Sprintf("Delete candidate #%d %s? [y/N] ", candidate.ID, candidate.Name), yes, config)
+	if err != nil || !confirmed {
+		return err
+	}
+	if err := setCandidateDeleted(id, true, db); err != nil {
+		return err
+	}
+	printWithColorln(fmt.Sprintf("Candidate #%d %s deleted.", candidate.ID, candidate.Name), yellow, config)
+	return nil
+}
+
+// mergeCandidate joins two records of the same person, e.g. an interview that was
+// started twice.
+func mergeCandidate(args []string, config *Config, db *sql.DB) error {
+	args, yes := assumeYes(args)
+	if len(args) != 2 {
+		return newCommandError("usage: mc <from-candidate-id> <into-candidate-id> [--yes]")
+	}
+	ids := make([]int, 0, 2)
+	for _, arg := range args {
+		id, err := parseCandidateID(arg)
+		if err != nil {
+			return err
+		}
+		if err := notRunning(id, config); err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	if ids[0] == ids[1] {
+		return newCommandError("a candidate can't be merged into itself")
+	}
+	from, err := getCandidate(ids[0], db)
+	if err != nil {
+		return err
+	}
+	into, err := getCandidate(ids[1], db)
+	if err != nil {
+		return err
+	}
+	if from.Deleted || into.Deleted {
+		return newCommandError("deleted candidates can't be merged")
+	}
+
+	confirmed, err := confirm(fmt.Sprintf("Move the answers of #%d %s to #%d %s and delete #%d? [y/N] ",
+		from.ID, from.Name, into.ID, into.Name, from.ID), yes, config)
+	if err != nil || !confirmed {
+		return err
+	}
+	if err := mergeCandidates(from.ID, into.ID, db); err != nil {
+		return err
+	}
+	printWithColorln(fmt.Sprintf("Candidate #%d merged into #%d %s.", from.ID, into.ID, into.Name), green, config)
+	return nil
+}
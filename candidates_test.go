@@ -0,0 +1,114 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_parseCandidateFilter(t *testing.T) {
+	filter, err := parseCandidateFilter(strings.Fields("leo messi --from 2020-06-01 --to 2020-06-30 --position backend --page 2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := candidateFilter{
+		name:     "leo messi",
+		position: "backend",
+		from:     time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC),
+		to:       time.Date(2020, 6, 30, 0, 0, 0, 0, time.UTC),
+		page:     2,
+	}
+	if filter != want {
+		t.Errorf("got=[%+v], want=[%+v]", filter, want)
+	}
+
+	if filter, _ := parseCandidateFilter([]string{}); filter.page != 1 {
+		t.Errorf("the first page is listed by default, got=[%d]", filter.page)
+	}
+
+	invalid := []string{"--from", "--from 06/01/2020", "--page 0", "--page x", "--sort name"}
+	for _, args := range invalid {
+		if _, err := parseCandidateFilter(strings.Fields(args)); err == nil {
+			t.Errorf("args=[%s]: want error", args)
+		}
+	}
+}
+
+func Test_candidateFilter_where(t *testing.T) {
+	where, args := candidateFilter{}.where()
+	if where != "deleted = 0" || len(args) != 0 {
+		t.Errorf("got where=[%s] args=%v", where, args)
+	}
+
+	to := time.Date(2020, 6, 30, 0, 0, 0, 0, time.UTC)
+	where, args = candidateFilter{name: "leo", to: to}.where()
+	if where != "deleted = 0 and name like ? and date < ?" {
+		t.Errorf("got where=[%s]", where)
+	}
+	if len(args) != 2 || args[0] != "%leo%" || !args[1].(time.Time).Equal(to.AddDate(0, 0, 1)) {
+		t.Errorf("got args=%v", args)
+	}
+}
+
+func Test_applyCandidateChanges(t *testing.T) {
+	candidate := CandidateView{Name: "Leo", Source: "referral"}
+	changes := map[string]string{"email": " leo@example.com ", "position": "backend", "source": "-"}
+	if err := applyCandidateChanges(&candidate, changes); err != nil {
+		t.Fatal(err)
+	}
+	if candidate.Email != "leo@example.com" || candidate.Position != "backend" || candidate.Source != "" {
+		t.Errorf("got=[%+v]", candidate)
+	}
+	// The columns are sized in characters, not bytes.
+	if err := applyCandidateChanges(&candidate, map[string]string{"seniority": strings.Repeat("ñ", 45)}); err != nil {
+		t.Errorf("45 characters fit in the seniority, got=[%v]", err)
+	}
+
+	invalid := []map[string]string{
+		{"name": ""},
+		{"email": "leo.example.com"},
+		{"age": "33"},
+		{"seniority": strings.Repeat("x", 46)},
+		{"seniority": strings.Repeat("ñ", 46)},
+	}
+	for _, changes := range invalid {
+		if err := applyCandidateChanges(&candidate, changes); err == nil {
+			t.Errorf("changes=%v: want error", changes)
+		}
+	}
+}
+
+func Test_readCandidateProfile(t *testing.T) {
+	config := NewConfig()
+	config.input = &typedLines{lines: []string{"leo@example.com", "", "sr", "", "plays football"}}
+	profile, err := readCandidateProfile(&config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"email": "leo@example.com", "seniority": "sr", "notes": "plays football"}
+	if len(profile) != len(want) {
+		t.Fatalf("got=%v, want=%v", profile, want)
+	}
+	for field, value := range want {
+		if profile[field] != value {
+			t.Errorf("%s: got=[%s], want=[%s]", field, profile[field], value)
+		}
+	}
+}
+
+func Test_mergeCandidate_running(t *testing.T) {
+	config := undoTestConfig()
+	config.intervieweeID = 7
+	if err := mergeCandidate([]string{"7", "8"}, &config, nil); err == nil {
+		t.Errorf("the candidate of the running interview can't be merged")
+	}
+	if err := mergeCandidate([]string{"8", "#8"}, &config, nil); err == nil {
+		t.Errorf("a candidate can't be merged into itself")
+	}
+	if err := mergeCandidate([]string{"8", "9", "10"}, &config, nil); err == nil {
+		t.Errorf("only two candidates can be merged")
+	}
+	if err := mergeCandidate([]string{"7", "8", "--yes"}, &config, nil); err == nil {
+		t.Errorf("--yes doesn't allow merging the candidate of the running interview")
+	}
+}
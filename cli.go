@@ -0,0 +1,285 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// subcommand is a batch operation run from the command line, e.g. "interview topics".
+type subcommand struct {
+	name  string
+	usage string
+	help  string
+	run   func(args []string, config *Config, db *sql.DB) error
+}
+
+var subcommands = []subcommand{
+	{name: "topics", usage: "[-with-questions]",
+		help: "lists the available topics.", run: topicsSubcommand},
+	{name: "candidates list", usage: "[-format text|csv]",
+		help: "lists the interviewed candidates.", run: candidatesListSubcommand},
+	{name: "answers", usage: "[-format text|csv] <candidate-id>",
+		help: "lists the answers given by a candidate.", run: answersSubcommand},
+	{name: "questions add", usage: "-topic <topic> -level <1|2|3> -question <text> [-answer <text>]",
+		help: "creates a question.", run: questionsAddSubcommand},
+	{name: "report", usage: "[-format text|csv] <candidate-id>",
+		help: "summarizes an interview by topic and level.", run: reportSubcommand},
+	{name: "rank", usage: "[-format text|csv] [-from <yyyy-mm-dd>] [-to <yyyy-mm-dd>] [-position <position>]",
+		help: "ranks the candidates by the score of their answers.", run: rankSubcommand},
+}
+
+// findSubcommand returns the subcommand named by the first words of args and the
+// arguments that follow its name.
+func findSubcommand(args []string) (subcommand, []string, bool) {
+	for _, sub := range subcommands {
+		name := strings.Fields(sub.name)
+		if len(args) < len(name) {
+			continue
+		}
+		if strings.Join(args[:len(name)], " ") == sub.name {
+			return sub, args[len(name):], true
+		}
+	}
+	return subcommand{}, []string{}, false
+}
+
+func runSubcommand(args []string, config *Config, db *sql.DB) error {
+	sub, rest, ok := findSubcommand(args)
+	if !ok {
+		printUsage(os.Stderr)
+		return fmt.Errorf("unknown command '%s'", strings.Join(args, " "))
+	}
+	return sub.run(rest, config, db)
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "usage:")
+	fmt.Fprintln(w, "\tinterview [-c commands]\t\tstarts the interactive prompt, or runs the given commands.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	for _, sub := range subcommands {
+		fmt.Fprintf(tw, "\tinterview %s %s\t%s\n", sub.name, sub.usage, sub.help)
+	}
+	tw.Flush()
+}
+
+func newFlagSet(sub string) *flag.FlagSet {
+	return flag.NewFlagSet("interview "+sub, flag.ContinueOnError)
+}
+
+func formatFlag(fs *flag.FlagSet) *string {
+	return fs.String("format", "text", "output format: text or csv")
+}
+
+func validateFormat(format string) error {
+	if format != "text" && format != "csv" {
+		return fmt.Errorf("unknown format '%s', use text or csv", format)
+	}
+	return nil
+}
+
+func candidateIDArg(fs *flag.FlagSet) (int, error) {
+	if fs.NArg() != 1 {
+		return 0, fmt.Errorf("expecting a single candidate id")
+	}
+	id, err := strconv.Atoi(fs.Arg(0))
+	if err != nil {
+		return 0, fmt.Errorf("'%s' is not a candidate id", fs.Arg(0))
+	}
+	return id, nil
+}
+
+func topicsSubcommand(args []string, config *Config, db *sql.DB) error {
+	fs := newFlagSet("topics")
+	withQuestions := fs.Bool("with-questions", false, "only list the topics that have questions")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if !*withQuestions {
+		return listTopics(config, db)
+	}
+	topics, err := getTopicsWithQuestions(db)
+	if err != nil {
+		return err
+	}
+	for _, topic := range topics {
+		fmt.Println(topic)
+	}
+	return nil
+}
+
+func candidatesListSubcommand(args []string, config *Config, db *sql.DB) error {
+	fs := newFlagSet("candidates list")
+	format := formatFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := validateFormat(*format); err != nil {
+		return err
+	}
+
+	candidates, err := getCandidates(db)
+	if err != nil {
+		return err
+	}
+	if *format == "text" {
+		for _, candidate := range candidates {
+			fmt.Println(candidate)
+		}
+		return nil
+	}
+
+	out := csv.NewWriter(os.Stdout)
+	out.Write([]string{"id", "name", "date"})
+	for _, candidate := range candidates {
+		out.Write([]string{strconv.Itoa(candidate.ID), candidate.Name, candidate.Date})
+	}
+	out.Flush()
+	return out.Error()
+}
+
+func answersSubcommand(args []string, config *Config, db *sql.DB) error {
+	fs := newFlagSet("answers")
+	format := formatFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := validateFormat(*format); err != nil {
+		return err
+	}
+	candidateID, err := candidateIDArg(fs)
+	if err != nil {
+		return err
+	}
+
+	if *format == "text" {
+		return listAnswers(candidateID, config, db)
+	}
+
+	answers, err := getAnswersFromCandidate(candidateID, db)
+	if err != nil {
+		return err
+	}
+	out := csv.NewWriter(os.Stdout)
+	out.Write([]string{"id", "question", "result", "comment", "topic", "level"})
+	for _, ans := range answers {
+		out.Write([]string{strconv.Itoa(ans.ID), ans.Question, Result(ans.Result).String(),
+			ans.Comment.String, ans.Topic, ans.Title})
+	}
+	out.Flush()
+	return out.Error()
+}
+
+func questionsAddSubcommand(args []string, config *Config, db *sql.DB) error {
+	fs := newFlagSet("questions add")
+	topicName := fs.String("topic", "", "topic of the question")
+	level := fs.Int("level", 0, "level of the question: 1) Programmer 2) Programmer Analyst 3) Sr. Programmer Analyst")
+	question := fs.String("question", "", "the question")
+	answer := fs.String("answer", "", "the reference answer")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if len(strings.TrimSpace(*question)) == 0 {
+		return fmt.Errorf("-question is required")
+	}
+	if *level < int(AssociateOrProgrammer) || *level > int(SrProgrammer) {
+		return fmt.Errorf("-level must be 1, 2 or 3")
+	}
+
+	topics, err := getTopics(db)
+	if err != nil {
+		return err
+	}
+	topicID := -1
+	for _, topic := range topics {
+		if topic.Topic == strings.ToLower(*topicName) {
+			topicID = topic.ID
+			break
+		}
+	}
+	if topicID == -1 {
+		return fmt.Errorf("topic '%s' not found", *topicName)
+	}
+
+	q := Question{Q: strings.TrimSpace(*question), Level: Level(*level), Result: NotAnsweredYet}
+	if err := saveQuestion(&q, topicID, strings.TrimSpace(*answer), db); err != nil {
+		return err
+	}
+	fmt.Println("Question created")
+	return nil
+}
+
+func reportSubcommand(args []string, config *Config, db *sql.DB) error {
+	fs := newFlagSet("report")
+	format := formatFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := validateFormat(*format); err != nil {
+		return err
+	}
+	candidateID, err := candidateIDArg(fs)
+	if err != nil {
+		return err
+	}
+
+	candidate, err := getCandidate(candidateID, db)
+	if err != nil {
+		return err
+	}
+	answers, err := getAnswersFromCandidate(candidateID, db)
+	if err != nil {
+		return err
+	}
+
+	report := buildReport(candidate, answers)
+	if *format == "csv" {
+		return writeReportCSV(os.Stdout, report)
+	}
+	printReport(os.Stdout, report)
+	return nil
+}
+
+func rankSubcommand(args []string, config *Config, db *sql.DB) error {
+	fs := newFlagSet("rank")
+	format := formatFlag(fs)
+	from := fs.String("from", "", "only the candidates interviewed from this day, yyyy-mm-dd")
+	to := fs.String("to", "", "only the candidates interviewed up to this day, yyyy-mm-dd")
+	position := fs.String("position", "", "only the candidates for this position")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := validateFormat(*format); err != nil {
+		return err
+	}
+
+	options := make([]string, 0)
+	for _, option := range [][2]string{{"--from", *from}, {"--to", *to}, {"--position", *position}} {
+		if len(option[1]) > 0 {
+			options = append(options, option[0], option[1])
+		}
+	}
+	filter, err := parseCandidateFilter(options)
+	if err != nil {
+		return err
+	}
+	ranking, err := loadRanking(filter, config.scoring, db)
+	if err != nil {
+		return err
+	}
+	if *format == "csv" {
+		return writeRankingCSV(os.Stdout, ranking)
+	}
+	printRanking(os.Stdout, ranking, config.scoring)
+	return nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_findSubcommand(t *testing.T) {
+	type test struct {
+		args  []string
+		found bool
+		name  string
+		rest  []string
+	}
+
+	tests := []test{
+		{args: []string{"topics"}, found: true, name: "topics", rest: []string{}},
+		{args: []string{"candidates", "list", "-format", "csv"}, found: true, name: "candidates list", rest: []string{"-format", "csv"}},
+		{args: []string{"report", "3"}, found: true, name: "report", rest: []string{"3"}},
+		{args: []string{"candidates"}, found: false},
+		{args: []string{"whatever"}, found: false},
+	}
+
+	for _, tt := range tests {
+		sub, rest, ok := findSubcommand(tt.args)
+		if ok != tt.found {
+			t.Errorf("args=%v, got=[%t], want=[%t]", tt.args, ok, tt.found)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if sub.name != tt.name || !EqualTopics(rest, tt.rest) {
+			t.Errorf("args=%v, got=[%s %v], want=[%s %v]", tt.args, sub.name, rest, tt.name, tt.rest)
+		}
+	}
+}
@@ -0,0 +1,714 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/muesli/termenv"
+)
+
+// unlimitedArgs marks a command that accepts any number of arguments.
+const unlimitedArgs = -1
+
+var errUnknownCommand = errors.New("unknown command")
+
+// commandHandler runs a command with the arguments typed after its name.
+type commandHandler func(args []string, config *Config, db *sql.DB) error
+
+// argCompleter returns the values the first argument of a command can take.
+type argCompleter func(config *Config, db *sql.DB) []string
+
+// commandSpec describes a command: the names it can be typed with, the arguments
+// it expects, its help text and the handler that runs it. Macros from interview.yaml
+// are commands too, marked with macro.
+type commandSpec struct {
+	names    []string
+	usage    string
+	minArgs  int
+	maxArgs  int
+	help     string
+	handler  commandHandler
+	complete argCompleter
+	undo     undoKind
+	macro    bool
+}
+
+// commandRegistry holds every available command in the order they are listed in help.
+type commandRegistry struct {
+	specs  []*commandSpec
+	byName map[string]*commandSpec
+}
+
+// commands is the registry used by the REPL, it is populated in init() because the
+// help handler needs to walk the registry itself.
+var commands *commandRegistry
+
+func init() {
+	commands = newCommandRegistry()
+}
+
+func newCommandRegistry() *commandRegistry {
+	registry := &commandRegistry{byName: make(map[string]*commandSpec)}
+
+	registry.register(&commandSpec{names: []string{"exit", "quit", ":q", "/q", "q"},
+		help: "exits from this application.", handler: exitHandler})
+	registry.register(&commandSpec{names: []string{"topics", "tps", "t", "/t", ":t"},
+		help: "list current available topics from the DB", handler: topicsHandler})
+	registry.register(&commandSpec{names: []string{"help", ":h", "/h", "--h", "-h", "h"},
+		help: "shows this message.", handler: helpHandler})
+	registry.register(&commandSpec{names: []string{"use", "u", "/u", ":u", "-u", "--u", "set"},
+		usage: "<topic>", minArgs: 1, maxArgs: 1,
+		help: "sets an available topic.", handler: useHandler, complete: completeTopics})
+	registry.register(&commandSpec{names: []string{"cls", "clear"},
+		help: "clears the screen.", handler: clearScreenHandler})
+	registry.register(&commandSpec{names: []string{"pwd"},
+		help: "prints the current selected topic.", handler: pwdHandler})
+	registry.register(&commandSpec{names: []string{"start", "begin"},
+		usage: "[--plan <plan>] [name]", maxArgs: unlimitedArgs,
+		help:    "starts the interview, following a plan when given; asks for the interviewee's name when it is not given.",
+		handler: startHandler})
+	registry.register(&commandSpec{names: []string{"print", "print()", "p", "p()"},
+		help: "prints the current question.", handler: printHandler})
+	registry.register(&commandSpec{undo: undoCursor, names: []string{"next", "nxt", ">"},
+		help: "moves to the next question.", handler: nextQuestionHandler})
+	registry.register(&commandSpec{undo: undoCursor, names: []string{"previous", "prev", "<"},
+		help: "moves to the previous question.", handler: previousQuestionHandler})
+	registry.register(&commandSpec{undo: undoCursor, names: []string{"goto", "go", "g"},
+		usage: "<question-id>|#<position>", minArgs: 1, maxArgs: 1,
+		help:    "jumps to a question by its ID or by its #position in the topic, switching level if needed.",
+		handler: gotoQuestionHandler, complete: completeQuestionIDs})
+	registry.register(&commandSpec{names: []string{"view", "v"},
+		help: "prints the current available questions by level, with their #position in the topic.", handler: viewHandler})
+	registry.register(&commandSpec{names: []string{"va"},
+		help: "view answer from current question", handler: viewCurrentQuestionAnswerHandler})
+	registry.register(&commandSpec{names: []string{"vas"},
+		help: "view answers from candidate", handler: viewAnswersHandler})
+	registry.register(&commandSpec{undo: undoAnswer, names: []string{"no", "n", "mal", "wrong", "nop", "bad", "nel"},
+		help: "marks a question as wrong.", handler: wrongAnswerHandler})
+	registry.register(&commandSpec{undo: undoAnswer, names: []string{"ok", "yes", "si", "right", "y"},
+		help: "marks a question as right / OK.", handler: rightAnswerHandler})
+	registry.register(&commandSpec{undo: undoAnswer, names: []string{"hmm", "meh", "?"},
+		help: "marks a question as neutral.", handler: mehAnswerHandler})
+	registry.register(&commandSpec{undo: undoAnswer, names: []string{"cmt", "comment", "note", "nt"},
+		usage: "[comment]", maxArgs: unlimitedArgs,
+		help:    "writes or edits the comment of the current question with $EDITOR, or inline, and saves it.",
+		handler: createCommentHandler})
+	registry.register(&commandSpec{names: []string{"finish", "done", "bye"},
+		help: "finishes an interview.", handler: finishHandler})
+	registry.register(&commandSpec{names: []string{"cq"},
+		usage: "[topic# level question answer]", maxArgs: 4,
+		help: "create a question and save it to the database.", handler: createQuestionHandler})
+	registry.register(&commandSpec{names: []string{"eq"},
+		usage: "<question-id> [<field> <value>...]", minArgs: 1, maxArgs: unlimitedArgs,
+		help:    "edits the question, answer, topic or level of a question, asking for each one when they are not given.",
+		handler: editQuestionHandler, complete: completeQuestionIDs})
+	registry.register(&commandSpec{names: []string{"retire"},
+		usage: "<question-id>", minArgs: 1,
+		help:    "retires a question: it is no longer loaded but its answers are kept.",
+		handler: retireQuestionHandler, complete: completeQuestionIDs})
+	registry.register(&commandSpec{undo: undoCursor, names: []string{"+"},
+		help:    "increases the level of the interview, e.g. from Programmer Analyst to Sr Programmer Analyst.",
+		handler: increaseLevelHandler})
+	registry.register(&commandSpec{undo: undoCursor, names: []string{"-"},
+		help: "decreases the level of the interview.", handler: decreaseLevelHandler})
+	registry.register(&commandSpec{undo: undoCursor, names: []string{"="},
+		help: "ignore levels.", handler: ignoreLevelHandler})
+	registry.register(&commandSpec{names: []string{"lvl"},
+		help: "prints the current interview level.", handler: showLevelHandler})
+	registry.register(&commandSpec{names: []string{"stats"},
+		help: "shows some stats and the current configuration for the interview.", handler: showStatsHandler})
+	registry.register(&commandSpec{undo: undoCursor, names: []string{"ap"},
+		help: `sets the level of the interview to "Associate Programmer"`, handler: levelHandler(AssociateOrProgrammer)})
+	registry.register(&commandSpec{undo: undoCursor, names: []string{"pa"},
+		help: `sets the level of the interview to "Programmer Analyst"`, handler: levelHandler(ProgrammerAnalyst)})
+	registry.register(&commandSpec{undo: undoCursor, names: []string{"sr"},
+		help: `sets the level of the interview to "Sr Programmer Analyst"`, handler: levelHandler(SrProgrammer)})
+	registry.register(&commandSpec{names: []string{"count", "cnt", "c"},
+		help: "prints how many questions the selected topic has per level.", handler: countHandler})
+	registry.register(&commandSpec{names: []string{"li"},
+		usage: "[name] [--from <yyyy-mm-dd>] [--to <yyyy-mm-dd>] [--position <position>] [--page <n>]", maxArgs: unlimitedArgs,
+		help: "lists the interviewed candidates, a page at a time.", handler: listCandidatesHandler})
+	registry.register(&commandSpec{names: []string{"ei"},
+		usage: "[candidate-id|text]", maxArgs: unlimitedArgs,
+		help:    "browses previous interviews: results by topic and level, each answer in detail, re-grades and reports.",
+		handler: exploreInterviewHandler, complete: completeCandidateIDs})
+	registry.register(&commandSpec{names: []string{"shuffle"},
+		usage: "[on|off|<seed>]", maxArgs: 1,
+		help:    "shuffles the questions of every level, a seed reproduces a previous sequence; without arguments prints the current setting.",
+		handler: shuffleHandler})
+	registry.register(&commandSpec{names: []string{"draw"},
+		usage: "<n>|all", minArgs: 1,
+		help: "asks only n random questions per level.", handler: drawHandler})
+	registry.register(&commandSpec{names: []string{"adaptive"},
+		usage: "[on|off|<up-streak> <down-streak>]", maxArgs: 2,
+		help:    "raises the level after a streak of OK marks and lowers it after a streak of wrong marks; without arguments prints the rules.",
+		handler: adaptiveHandler})
+	registry.register(&commandSpec{names: []string{"plan"},
+		usage: "[next|list]", maxArgs: 1,
+		help:    "shows the progress of the interview plan, next skips to the next step and list shows the plans.",
+		handler: planHandler})
+	registry.register(&commandSpec{names: []string{"timebox", "tb"},
+		usage: "[<total>] [<topic>=<budget>...]|off", maxArgs: unlimitedArgs,
+		help:    "sets the time budgets of the interview and its topics, e.g. timebox 45m java=15m; without arguments prints them.",
+		handler: timeboxHandler})
+	registry.register(&commandSpec{names: []string{"undo"},
+		help: "undoes the last mark, comment, level change or move, restoring the saved answer.", handler: undoHandler})
+	registry.register(&commandSpec{names: []string{"redo"},
+		help: "redoes the last undone command.", handler: redoHandler})
+	registry.register(&commandSpec{names: []string{"prompt"},
+		usage: "[<template>|default]", maxArgs: unlimitedArgs,
+		help:    "sets the prompt, e.g. prompt {topic|faint} {level|cyan} {position} {answered}/{total} {ps1}; without arguments prints it.",
+		handler: promptHandler})
+	registry.register(&commandSpec{names: []string{"theme"},
+		usage: "[dark|light|high-contrast]", maxArgs: 1,
+		help: "sets the color theme; without arguments prints the current one.", handler: themeHandler, complete: completeThemes})
+	registry.register(&commandSpec{names: []string{"topic"},
+		usage: "add|rename|describe|merge|delete <topic> [...] [--yes]", minArgs: 1, maxArgs: unlimitedArgs,
+		help:    "creates, renames, describes, merges or deletes a topic, e.g. topic merge j2ee java.",
+		handler: manageTopicsHandler, complete: completeTopicActions})
+	registry.register(&commandSpec{names: []string{"ec"},
+		usage: "<candidate-id> [<field> <value>...]", minArgs: 1, maxArgs: unlimitedArgs,
+		help:    "edits the name, email, position, seniority, source or notes of a candidate, asking for each one when they are not given.",
+		handler: editCandidateHandler, complete: completeCandidateIDs})
+	registry.register(&commandSpec{names: []string{"search", "fc"},
+		usage: "<text>", minArgs: 1, maxArgs: unlimitedArgs,
+		help: "finds the candidates whose name, email, position, source or notes contain the text.", handler: searchCandidatesHandler})
+	registry.register(&commandSpec{names: []string{"dc"},
+		usage: "<candidate-id> [--yes]", minArgs: 1, maxArgs: 2,
+		help:    "deletes a candidate: it is no longer listed but the interview is kept.",
+		handler: deleteCandidateHandler, complete: completeCandidateIDs})
+	registry.register(&commandSpec{names: []string{"mc"},
+		usage: "<from-candidate-id> <into-candidate-id> [--yes]", minArgs: 2, maxArgs: 3,
+		help:    "moves the answers of a candidate to another one and deletes the first one.",
+		handler: mergeCandidatesHandler, complete: completeCandidateIDs})
+	registry.register(&commandSpec{names: []string{"compare", "cmp"},
+		usage: "<candidate-id> <candidate-id>...", minArgs: 2, maxArgs: unlimitedArgs,
+		help:    "lines up the answers of several candidates with their results by topic and level and their weighted totals.",
+		handler: compareCandidatesHandler, complete: completeCandidateIDs})
+	registry.register(&commandSpec{names: []string{"rank"},
+		usage: "[name] [--from <yyyy-mm-dd>] [--to <yyyy-mm-dd>] [--position <position>] [--csv <file>]", maxArgs: unlimitedArgs,
+		help:    "ranks the candidates by the score of their answers, the formula is set in interview.yaml; --csv exports the ranking.",
+		handler: rankCandidatesHandler})
+	registry.register(&commandSpec{names: []string{"qstats"},
+		usage: "[topic] [--min <times-asked>]", maxArgs: unlimitedArgs,
+		help:    "prints how often each question was asked, its results and how well it separates strong from weak candidates.",
+		handler: questionStatsHandler, complete: completeTopics})
+	registry.register(&commandSpec{names: []string{"coverage"},
+		usage: "[topic]", maxArgs: 1,
+		help:    "prints the questions of each topic and level, how many were ever asked and when, and how many lack a reference answer.",
+		handler: coverageHandler, complete: completeTopics})
+	registry.register(&commandSpec{names: []string{"tui", "fs"},
+		help: "runs the interview in full-screen mode.", handler: tuiHandler})
+
+	return registry
+}
+
+func (r *commandRegistry) register(spec *commandSpec) {
+	if spec.maxArgs < spec.minArgs && spec.maxArgs != unlimitedArgs {
+		spec.maxArgs = spec.minArgs
+	}
+	for _, name := range spec.names {
+		if _, taken := r.byName[name]; taken {
+			panic(fmt.Sprintf("command name '%s' registered twice", name))
+		}
+		r.byName[name] = spec
+	}
+	r.specs = append(r.specs, spec)
+}
+
+// names returns every name and alias a command can be typed with.
+func (r *commandRegistry) names() []string {
+	names := make([]string, 0, len(r.byName))
+	for name := range r.byName {
+		names = append(names, name)
+	}
+	return names
+}
+
+func (r *commandRegistry) lookup(name string) (*commandSpec, bool) {
+	spec, ok := r.byName[strings.ToLower(sanitizeUserInput(name))]
+	return spec, ok
+}
+
+// parse splits the user's input into the command it refers to and its arguments,
+// checking the number of arguments against the command's spec.
+func (r *commandRegistry) parse(input string) (*commandSpec, []string, error) {
+	fullCommand := words(input)
+	if len(fullCommand) == 0 {
+		return nil, []string{}, errUnknownCommand
+	}
+	spec, ok := r.lookup(fullCommand[0])
+	if !ok {
+		return nil, []string{}, errUnknownCommand
+	}
+	args := fullCommand[1:]
+	if err := spec.validateArgs(args); err != nil {
+		return spec, []string{}, err
+	}
+	return spec, args, nil
+}
+
+func (spec *commandSpec) validateArgs(args []string) error {
+	if len(args) < spec.minArgs || (spec.maxArgs != unlimitedArgs && len(args) > spec.maxArgs) {
+		return fmt.Errorf("usage: %s", spec.synopsis())
+	}
+	return nil
+}
+
+func (spec *commandSpec) name() string {
+	return spec.names[0]
+}
+
+func (spec *commandSpec) synopsis() string {
+	if len(spec.usage) == 0 {
+		return spec.name()
+	}
+	return fmt.Sprintf("%s %s", spec.name(), spec.usage)
+}
+
+func (r *commandRegistry) printHelp() {
+	fmt.Println()
+	fmt.Println("commands:")
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	for _, spec := range r.specs {
+		names := strings.Join(spec.names, "|")
+		if len(spec.usage) != 0 {
+			names = fmt.Sprintf("%s %s", names, spec.usage)
+		}
+		fmt.Fprintf(w, "\t%s\t%s\n", names, spec.help)
+	}
+	w.Flush()
+
+	fmt.Println()
+	fmt.Println("\tAny other command or sentence that is not listed here will be simply ignored.")
+	fmt.Println()
+}
+
+func exitHandler(args []string, config *Config, db *sql.DB) error {
+	printWithColorln("Bye", magenta, config)
+	os.Exit(0)
+	return nil
+}
+
+func topicsHandler(args []string, config *Config, db *sql.DB) error {
+	return listTopics(config, db)
+}
+
+func helpHandler(args []string, config *Config, db *sql.DB) error {
+	commands.printHelp()
+	return nil
+}
+
+func clearScreenHandler(args []string, config *Config, db *sql.DB) error {
+	clearScreen()
+	return nil
+}
+
+func pwdHandler(args []string, config *Config, db *sql.DB) error {
+	fmt.Println(style(config, config.selectedTopic).Bold())
+	return nil
+}
+
+func useHandler(args []string, config *Config, db *sql.DB) error {
+	return setTopic(args, config, db)
+}
+
+func startHandler(args []string, config *Config, db *sql.DB) error {
+	if config.hasStarted {
+		return newCommandError("Interview has already started.")
+	}
+
+	planName, args, err := extractPlanName(args)
+	if err != nil {
+		return err
+	}
+	var plan *interviewPlan
+	if len(planName) > 0 {
+		p, err := findPlan(planName, config)
+		if err != nil {
+			return err
+		}
+		plan = &p
+	}
+
+	if len(config.selectedTopic) == 0 && plan == nil {
+		return newCommandError("You need to select a topic first.")
+	}
+
+	line := strings.Join(args, " ")
+	if len(args) == 0 {
+		if line, err = config.input.readLine("Interviewee name: "); err != nil {
+			return err
+		}
+	}
+	name, ok := readIntervieweeName(strings.NewReader(line))
+	if !ok {
+		return nil
+	}
+	var candidate CandidateView
+	if err := applyCandidateChanges(&candidate, map[string]string{"name": name}); err != nil {
+		return err
+	}
+	name = candidate.Name
+	profile := map[string]string{}
+	if len(args) == 0 {
+		if profile, err = readCandidateProfile(config); err != nil {
+			return err
+		}
+		if err := applyCandidateChanges(&candidate, profile); err != nil {
+			return err
+		}
+	}
+	id, err := saveIntervieweeName(name, db)
+	if err != nil {
+		return err
+	}
+	if candidate.ID = id; len(profile) > 0 {
+		if err := updateCandidate(&candidate, db); err != nil {
+			return err
+		}
+	}
+	config.intervieweeID = id
+	// The plan is followed once the candidate is saved, so a cancelled start leaves no plan behind.
+	if plan != nil {
+		config.plan, config.planStep = plan, 0
+		if err := activatePlanStep(config, db); err != nil {
+			config.plan = nil
+			return err
+		}
+	}
+	if config.shuffle {
+		if err := saveQuestionSampling(id, config, db); err != nil {
+			return err
+		}
+		printWithColorln(fmt.Sprintf("Questions %s, reproduce them with: %s",
+			samplingDescription(config), reproduceCommands(config.seed, config.drawCount)), cyan, config)
+	}
+	config.interview.Interviewee = name
+	config.interview.Date = time.Now()
+	config.hasStarted = true
+	config.timebox.track(config.selectedTopic, config.interview.Date)
+	// Message to the user that the interview has started.
+	printQuestion(config.questionIndex, config)
+	return nil
+}
+
+func printHandler(args []string, config *Config, db *sql.DB) error {
+	printQuestion(config.questionIndex, config)
+	return nil
+}
+
+func nextQuestionHandler(args []string, config *Config, db *sql.DB) error {
+	gotoNextQuestion(config)
+	printQuestion(config.questionIndex, config)
+	return nil
+}
+
+func previousQuestionHandler(args []string, config *Config, db *sql.DB) error {
+	gotoPreviousQuestion(config)
+	printQuestion(config.questionIndex, config)
+	return nil
+}
+
+func gotoQuestionHandler(args []string, config *Config, db *sql.DB) error {
+	if len(config.selectedTopic) == 0 {
+		return newCommandError("You need to select a topic first.")
+	}
+	index, err := findQuestionIndex(args[0], config.interview.Topics[config.selectedTopic])
+	if err != nil {
+		return err
+	}
+	moveToQuestion(index, config)
+	printQuestion(config.questionIndex, config)
+	return nil
+}
+
+func viewHandler(args []string, config *Config, db *sql.DB) error {
+	if !config.ignoreLevelChecking {
+		viewQuestionsByLevel(config)
+	} else {
+		viewQuestions(config)
+	}
+	return nil
+}
+
+func rightAnswerHandler(args []string, config *Config, db *sql.DB) error {
+	return markAnswer(config, OK, green, setAnswerAsOK, db)
+}
+
+func wrongAnswerHandler(args []string, config *Config, db *sql.DB) error {
+	return markAnswer(config, Wrong, red, setAnswerAsWrong, db)
+}
+
+func mehAnswerHandler(args []string, config *Config, db *sql.DB) error {
+	return markAnswer(config, Neutral, yellow, setAnswerAsNeutral, db)
+}
+
+func markAnswer(config *Config, ans Result, messageColorCode string,
+	ignoringLevels func(*Config, *sql.DB) error, db *sql.DB) error {
+	if !config.hasStarted {
+		return newCommandError("Interview has not yet started.")
+	}
+	if config.ignoreLevelChecking {
+		if err := ignoringLevels(config, db); err != nil {
+			return err
+		}
+		return advancePlan(config, db)
+	}
+	if err := answerAs(config, ans, messageColorCode, db); err != nil {
+		return err
+	}
+	if err := announceLevelChange(adaptLevel(ans, config), config, db); err != nil {
+		return err
+	}
+	return advancePlan(config, db)
+}
+
+func finishHandler(args []string, config *Config, db *sql.DB) error {
+	if config.hasStarted {
+		printTimeSummary(config, time.Now())
+	}
+	if err := removeSession(sessionPath()); err != nil {
+		return err
+	}
+	printWithColorln(fmt.Sprintf("Interview for '%s' has been saved.\n\n\tBye ...", config.interview.Interviewee), green, config)
+	os.Exit(0)
+	return nil
+}
+
+func editQuestionHandler(args []string, config *Config, db *sql.DB) error {
+	return editQuestion(args, config, db)
+}
+
+func retireQuestionHandler(args []string, config *Config, db *sql.DB) error {
+	return retireQuestion(args, config, db)
+}
+
+func increaseLevelHandler(args []string, config *Config, db *sql.DB) error {
+	increaseLevel(config)
+	return nil
+}
+
+func decreaseLevelHandler(args []string, config *Config, db *sql.DB) error {
+	decreaseLevel(config)
+	return nil
+}
+
+func ignoreLevelHandler(args []string, config *Config, db *sql.DB) error {
+	toggleLevelChecking(config)
+	return nil
+}
+
+func showLevelHandler(args []string, config *Config, db *sql.DB) error {
+	showLevel(config)
+	return nil
+}
+
+func showStatsHandler(args []string, config *Config, db *sql.DB) error {
+	return showStats(config, db)
+}
+
+func levelHandler(lvl Level) commandHandler {
+	return func(args []string, config *Config, db *sql.DB) error {
+		setLevel(lvl, config)
+		return nil
+	}
+}
+
+func countHandler(args []string, config *Config, db *sql.DB) error {
+	showCounts(config)
+	return nil
+}
+
+func createCommentHandler(args []string, config *Config, db *sql.DB) error {
+	return editComment(args, config, db)
+}
+
+func createQuestionHandler(args []string, config *Config, db *sql.DB) error {
+	if err := makeQuestion(args, config, db); err != nil {
+		return err
+	}
+	printWithColorln("Question created", magenta, config)
+	return nil
+}
+
+func viewCurrentQuestionAnswerHandler(args []string, config *Config, db *sql.DB) error {
+	viewAnswer(config.questionIndex, config)
+	return nil
+}
+
+func viewAnswersHandler(args []string, config *Config, db *sql.DB) error {
+	return listAnswers(config.intervieweeID, config, db)
+}
+
+func listCandidatesHandler(args []string, config *Config, db *sql.DB) error {
+	return listCandidates(args, config, db)
+}
+
+func exploreInterviewHandler(args []string, config *Config, db *sql.DB) error {
+	return exploreInterview(args, config, db)
+}
+
+func shuffleHandler(args []string, config *Config, db *sql.DB) error {
+	if len(args) == 0 {
+		fmt.Printf("Questions: ")
+		printWithColorf(config, "%s\n", green, samplingDescription(config))
+		return nil
+	}
+	if config.hasStarted {
+		return newCommandError("The questions can't be shuffled once the interview has started.")
+	}
+	if err := setShuffle(args[0], config); err != nil {
+		return err
+	}
+	return reloadTopic(config, db)
+}
+
+func drawHandler(args []string, config *Config, db *sql.DB) error {
+	if config.hasStarted {
+		return newCommandError("The questions can't be drawn once the interview has started.")
+	}
+	if err := setDraw(args[0], config); err != nil {
+		return err
+	}
+	return reloadTopic(config, db)
+}
+
+func adaptiveHandler(args []string, config *Config, db *sql.DB) error {
+	if len(args) > 0 {
+		if err := setAdaptive(args, config); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Adaptive level: ")
+	printWithColorf(config, "%s\n", green, config.adaptive)
+	return nil
+}
+
+func planHandler(args []string, config *Config, db *sql.DB) error {
+	option := ""
+	if len(args) > 0 {
+		option = strings.ToLower(args[0])
+	}
+	switch {
+	case option == "list" || (option == "" && config.plan == nil):
+		printPlans(config)
+		return nil
+	case config.plan == nil:
+		return newCommandError("There is no plan, start the interview with start --plan <plan>.")
+	case option == "next":
+		return nextPlanStep(config, db)
+	case option == "":
+		printPlanProgress(config)
+		return nil
+	}
+	return newCommandError("unknown option '%s', use plan, plan next or plan list", option)
+}
+
+func timeboxHandler(args []string, config *Config, db *sql.DB) error {
+	if err := config.timebox.setBudgets(args); err != nil {
+		return err
+	}
+	config.timebox.warned = make(map[string]bool)
+	printTimebox(config, time.Now())
+	return nil
+}
+
+func undoHandler(args []string, config *Config, db *sql.DB) error {
+	return undo(config, db)
+}
+
+func redoHandler(args []string, config *Config, db *sql.DB) error {
+	return redo(config, db)
+}
+
+func promptHandler(args []string, config *Config, db *sql.DB) error {
+	template := strings.Join(args, " ")
+	switch {
+	case len(args) == 0:
+		if len(config.promptTemplate) == 0 {
+			fmt.Println("The prompt is the default one.")
+		} else {
+			fmt.Printf("Prompt: %q\n", config.promptTemplate)
+		}
+		fmt.Printf("Placeholders: %s, styled with {topic|faint}, {level|bold}, {left|red} ...\n", promptFieldNames())
+		return nil
+	case strings.ToLower(template) == "default":
+		config.promptTemplate = ""
+		return nil
+	}
+	if err := validatePromptTemplate(template); err != nil {
+		return newCommandError("%s", err)
+	}
+	if !strings.HasSuffix(template, " ") && !strings.HasSuffix(template, "{ps1}") {
+		template += " "
+	}
+	config.promptTemplate = template
+	return nil
+}
+
+func themeHandler(args []string, config *Config, db *sql.DB) error {
+	if len(args) > 0 {
+		t, err := findTheme(args[0])
+		if err != nil {
+			return newCommandError("%s", err)
+		}
+		config.theme = t
+	}
+	fmt.Printf("Theme: ")
+	printWithColorf(config, "%s\n", green, config.theme.name)
+	if config.colorProfile == termenv.Ascii {
+		fmt.Println("Colors are off, NO_COLOR is set or the output is not a terminal.")
+	}
+	return nil
+}
+
+func manageTopicsHandler(args []string, config *Config, db *sql.DB) error {
+	return manageTopics(args, config, db)
+}
+
+func editCandidateHandler(args []string, config *Config, db *sql.DB) error {
+	return editCandidate(args, config, db)
+}
+
+func searchCandidatesHandler(args []string, config *Config, db *sql.DB) error {
+	return searchCandidates(args, config, db)
+}
+
+func deleteCandidateHandler(args []string, config *Config, db *sql.DB) error {
+	return deleteCandidate(args, config, db)
+}
+
+func mergeCandidatesHandler(args []string, config *Config, db *sql.DB) error {
+	return mergeCandidate(args, config, db)
+}
+
+func compareCandidatesHandler(args []string, config *Config, db *sql.DB) error {
+	return compareCandidates(args, config, db)
+}
+
+func rankCandidatesHandler(args []string, config *Config, db *sql.DB) error {
+	return rankCandidatesCommand(args, config, db)
+}
+
+func questionStatsHandler(args []string, config *Config, db *sql.DB) error {
+	return questionStatistics(args, config, db)
+}
+
+func coverageHandler(args []string, config *Config, db *sql.DB) error {
+	return questionCoverage(args, config, db)
+}
+
+func tuiHandler(args []string, config *Config, db *sql.DB) error {
+	if !config.hasStarted {
+		return newCommandError("Interview has not yet started.")
+	}
+	return runTUI(config, db)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_commandRegistry_parse(t *testing.T) {
+	type test struct {
+		input   string
+		want    string
+		args    []string
+		wantErr bool
+	}
+
+	tests := []test{
+		{input: "use java", want: "use", args: []string{"java"}},
+		{input: "USE java", want: "use", args: []string{"java"}},
+		{input: "use", want: "use", args: []string{}, wantErr: true},
+		{input: "use java sql", want: "use", args: []string{}, wantErr: true},
+		{input: "next", want: "next", args: []string{}},
+		{input: "next 3", want: "next", args: []string{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		spec, args, err := commands.parse(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("input=[%s], got error=[%v], want error=[%t]", tt.input, err, tt.wantErr)
+		}
+		if spec == nil || spec.name() != tt.want {
+			t.Errorf("input=[%s], got=[%v], want=[%s]", tt.input, spec, tt.want)
+			continue
+		}
+		if !EqualTopics(args, tt.args) {
+			t.Errorf("input=[%s], got=[%v], want=[%v]", tt.input, args, tt.args)
+		}
+	}
+
+	if _, _, err := commands.parse("whatever"); err != errUnknownCommand {
+		t.Errorf("got=[%v], want=[%v]", err, errUnknownCommand)
+	}
+}
+
+func Test_commandRegistry_specs(t *testing.T) {
+	for _, spec := range commands.specs {
+		if spec.handler == nil {
+			t.Errorf("%s has no handler", spec.name())
+		}
+		if len(spec.help) == 0 {
+			t.Errorf("%s has no help text", spec.name())
+		}
+		for _, name := range spec.names {
+			if got, ok := commands.lookup(name); !ok || got != spec {
+				t.Errorf("%s: '%s' is not registered", spec.name(), name)
+			}
+		}
+	}
+
+	for _, name := range []string{"exit", "use", "start", "next", "ok", "cmt", "finish", "undo", "topic", "compare", "rank", "qstats", "coverage", "tui"} {
+		if _, ok := commands.lookup(name); !ok {
+			t.Errorf("%s is not registered", name)
+		}
+	}
+}
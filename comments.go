@@ -0,0 +1,139 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"unicode/utf8"
+)
+
+// editComment lets the interviewer write or edit the comment of the current question,
+// the comment is saved right away whether the question has been marked or not.
+func editComment(args []string, config *Config, db *sql.DB) error {
+	if !config.hasStarted {
+		return newCommandError("Interview has not yet started.")
+	}
+	q, ok := currentQuestion(config)
+	if !ok {
+		return newCommandError("There are no questions for this level.")
+	}
+
+	existing, err := getAnswerComment(config.intervieweeID, q.ID, db)
+	if err != nil {
+		return err
+	}
+
+	var comment string
+	switch {
+	case len(args) > 0:
+		comment = strings.Join(args, " ")
+	case !config.input.interactive():
+		return newCommandError("pass the comment inline, e.g. cmt knows about generics")
+	case len(editorCommand()) > 0:
+		comment, err = editInEditor(existing)
+	default:
+		comment, err = readComment(existing, config.input)
+	}
+	if err != nil {
+		return err
+	}
+
+	comment = strings.TrimSpace(comment)
+	if comment == existing {
+		printWithColorln("Comment unchanged.", yellow, config)
+		return nil
+	}
+	if n := utf8.RuneCountInString(comment); n > maxCommentLength {
+		return newCommandError("the comment has %d characters, the limit is %d", n, maxCommentLength)
+	}
+
+	if err := saveComment(config.intervieweeID, q.ID, comment, db); err != nil {
+		return err
+	}
+	qs := config.interview.Topics[config.selectedTopic]
+	setQuestionComment(q.ID, comment, &qs)
+	printWithColorln("Comment saved.", magenta, config)
+	return nil
+}
+
+// editorCommand returns the editor chosen by the interviewer through $VISUAL or $EDITOR.
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.Fields(os.Getenv(env)); len(editor) > 0 {
+			return editor
+		}
+	}
+	return []string{}
+}
+
+func editInEditor(existing string) (string, error) {
+	f, err := ioutil.TempFile("", "interview-comment-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.WriteString(existing); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	editor := editorCommand()
+	cmd := exec.Command(editor[0], append(editor[1:], f.Name())...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+
+	content, err := ioutil.ReadFile(f.Name())
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// readComment is the inline editor used when there is no $EDITOR, the comment ends
+// with a line holding a single '.' or with Ctrl-D.
+func readComment(existing string, input lineReader) (string, error) {
+	if len(existing) > 0 {
+		fmt.Printf("Current comment:\n%s\n\n", existing)
+		fmt.Println("Type the new comment, an empty one keeps the current comment.")
+	}
+	fmt.Println("Finish the comment with a line holding a single '.' or with Ctrl-D.")
+
+	lines := make([]string, 0)
+	for {
+		line, err := input.readLine("> ")
+		if err == io.EOF || strings.TrimSpace(line) == "." {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, line)
+	}
+
+	comment := strings.Join(lines, "\n")
+	if len(strings.TrimSpace(comment)) == 0 {
+		return existing, nil
+	}
+	return comment, nil
+}
+
+func setQuestionComment(id int, comment string, qs *[]Question) {
+	for i, q := range *qs {
+		if q.ID == id {
+			(*qs)[i].Comment = comment
+			break
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// typedLines is a lineReader that returns the given lines and then io.EOF.
+type typedLines struct {
+	lines []string
+}
+
+func (t *typedLines) readCommand(prompt string) (string, error) {
+	return t.readLine(prompt)
+}
+
+func (t *typedLines) readLine(prompt string) (string, error) {
+	if len(t.lines) == 0 {
+		return "", io.EOF
+	}
+	line := t.lines[0]
+	t.lines = t.lines[1:]
+	return line, nil
+}
+
+func (t *typedLines) interactive() bool {
+	return true
+}
+
+func (t *typedLines) close() error {
+	return nil
+}
+
+func Test_readComment(t *testing.T) {
+	type test struct {
+		existing string
+		lines    []string
+		want     string
+	}
+
+	tests := []test{
+		{existing: "", lines: []string{"knows generics", "but not streams", ".", "ignored"}, want: "knows generics\nbut not streams"},
+		{existing: "", lines: []string{"until Ctrl-D"}, want: "until Ctrl-D"},
+		{existing: "previous", lines: []string{"", "."}, want: "previous"},
+		{existing: "previous", lines: []string{"replaced", "."}, want: "replaced"},
+	}
+
+	for _, tt := range tests {
+		got, err := readComment(tt.existing, &typedLines{lines: tt.lines})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("got=[%q], want=[%q]", got, tt.want)
+		}
+	}
+}
+
+func Test_editInEditor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "interview")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	editor := filepath.Join(dir, "editor.sh")
+	script := "#!/bin/sh\necho \"$(cat \"$1\") and streams\" > \"$1\"\n"
+	if err := ioutil.WriteFile(editor, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv("VISUAL", "")
+	os.Setenv("EDITOR", editor)
+	defer os.Unsetenv("EDITOR")
+
+	got, err := editInEditor("knows generics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "knows generics and streams\n"; got != want {
+		t.Errorf("got=[%q], want=[%q]", got, want)
+	}
+}
+
+func Test_setQuestionComment(t *testing.T) {
+	qs := []Question{
+		Question{ID: 30},
+		Question{ID: 31},
+	}
+	setQuestionComment(31, "good", &qs)
+	if qs[1].Comment != "good" || qs[0].Comment != "" {
+		t.Errorf("got=[%+v]", qs)
+	}
+}
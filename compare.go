@@ -0,0 +1,209 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// maxComparedQuestionLength keeps the question column of compare readable.
+const maxComparedQuestionLength = 50
+
+// levelShortNames are the names the levels are typed with, e.g. ap in "plan" steps.
+var levelShortNames = map[Level]string{AssociateOrProgrammer: "ap", ProgrammerAnalyst: "pa", SrProgrammer: "sr"}
+
+// comparedQuestion is a question asked to at least one of the compared candidates, the
+// results are in the order of the candidates and 0 means it was not asked.
+type comparedQuestion struct {
+	id       int
+	question string
+	topic    string
+	level    Level
+	results  []Result
+	askedBy  int
+}
+
+func (q comparedQuestion) askedToAll() bool {
+	return q.askedBy == len(q.results)
+}
+
+// comparison lines up the answers of several candidates.
+type comparison struct {
+	candidates  []CandidateView
+	questions   []comparedQuestion
+	topics      []string
+	byTopic     map[string][]score
+	levels      []Level
+	levelTitles map[Level]string
+	byLevel     map[Level][]score
+	totals      []score
+}
+
+func buildComparison(candidates []CandidateView, answers [][]AnswerView, w scoreWeights) comparison {
+	n := len(candidates)
+	cmp := comparison{
+		candidates:  candidates,
+		byTopic:     make(map[string][]score),
+		levelTitles: make(map[Level]string),
+		byLevel:     make(map[Level][]score),
+		totals:      make([]score, n),
+	}
+	questions := make(map[int]*comparedQuestion)
+	for i, candidateAnswers := range answers {
+		for _, ans := range candidateAnswers {
+			q, ok := questions[ans.QuestionID]
+			if !ok {
+				q = &comparedQuestion{id: ans.QuestionID, question: ans.Question, topic: ans.Topic, level: ans.Level,
+					results: make([]Result, n)}
+				questions[ans.QuestionID] = q
+			}
+			q.results[i] = Result(ans.Result)
+			// A comment on a question that wasn't marked doesn't make it asked.
+			if q.results[i] != NotAnsweredYet {
+				q.askedBy++
+			}
+
+			if _, ok := cmp.byTopic[ans.Topic]; !ok {
+				cmp.byTopic[ans.Topic] = make([]score, n)
+				cmp.topics = append(cmp.topics, ans.Topic)
+			}
+			if _, ok := cmp.byLevel[ans.Level]; !ok {
+				cmp.byLevel[ans.Level] = make([]score, n)
+				cmp.levels = append(cmp.levels, ans.Level)
+				cmp.levelTitles[ans.Level] = ans.Title
+			}
+			cmp.byTopic[ans.Topic][i].add(ans, w)
+			cmp.byLevel[ans.Level][i].add(ans, w)
+			cmp.totals[i].add(ans, w)
+		}
+	}
+
+	for _, q := range questions {
+		cmp.questions = append(cmp.questions, *q)
+	}
+	sort.Slice(cmp.questions, func(i, j int) bool {
+		a, b := cmp.questions[i], cmp.questions[j]
+		if a.topic != b.topic {
+			return a.topic < b.topic
+		}
+		if a.level != b.level {
+			return a.level < b.level
+		}
+		return a.id < b.id
+	})
+	sort.Strings(cmp.topics)
+	sort.Slice(cmp.levels, func(i, j int) bool { return cmp.levels[i] < cmp.levels[j] })
+	return cmp
+}
+
+// shortQuestion cuts a question to maxComparedQuestionLength characters, counted in
+// runes so a multi-byte character is never split.
+func shortQuestion(text string) string {
+	runes := []rune(text)
+	if len(runes) <= maxComparedQuestionLength {
+		return text
+	}
+	return string(runes[:maxComparedQuestionLength-3]) + "..."
+}
+
+func printComparison(w io.Writer, cmp comparison) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := []string{"QUESTION"}
+	for _, c := range cmp.candidates {
+		header = append(header, fmt.Sprintf("#%d %s", c.ID, c.Name))
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	partial := 0
+	for _, q := range cmp.questions {
+		flag := " "
+		if !q.askedToAll() {
+			flag = "*"
+			partial++
+		}
+		text := shortQuestion(q.question)
+		row := []string{fmt.Sprintf("%sQ%d %s %s: %s", flag, q.id, q.topic, levelShortNames[q.level], text)}
+		for _, result := range q.results {
+			row = append(row, comparedResult(result))
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	scoreRow := func(name string, scores []score) {
+		row := []string{name}
+		for _, s := range scores {
+			row = append(row, s.String())
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	fmt.Fprintln(tw, "\t")
+	fmt.Fprintln(tw, "TOPIC\t")
+	for _, topic := range cmp.topics {
+		scoreRow(topic, cmp.byTopic[topic])
+	}
+	fmt.Fprintln(tw, "\t")
+	fmt.Fprintln(tw, "LEVEL\t")
+	for _, level := range cmp.levels {
+		scoreRow(cmp.levelTitles[level], cmp.byLevel[level])
+	}
+	fmt.Fprintln(tw, "\t")
+	scoreRow("WEIGHTED TOTAL", cmp.totals)
+	tw.Flush()
+
+	if partial > 0 {
+		fmt.Fprintf(w, "\n* %d of %d questions were not asked to every candidate.\n", partial, len(cmp.questions))
+	}
+}
+
+func comparedResult(result Result) string {
+	switch result {
+	case 0:
+		return "-"
+	case NotAnsweredYet:
+		return "?"
+	}
+	return result.String()
+}
+
+// compareCandidates prints the answers of several candidates side by side.
+func compareCandidates(args []string, config *Config, db *sql.DB) error {
+	candidates := make([]CandidateView, 0, len(args))
+	answers := make([][]AnswerView, 0, len(args))
+	seen := make(map[int]bool)
+	for _, arg := range args {
+		id, err := parseCandidateID(arg)
+		if err != nil {
+			return err
+		}
+		if seen[id] {
+			return newCommandError("candidate #%d is compared twice", id)
+		}
+		seen[id] = true
+
+		candidate, err := getCandidate(id, db)
+		if err != nil {
+			return err
+		}
+		if candidate.Deleted {
+			return newCommandError("candidate #%d was deleted", id)
+		}
+		candidateAnswers, err := getAnswersFromCandidate(id, db)
+		if err != nil {
+			return err
+		}
+		candidates = append(candidates, candidate)
+		answers = append(answers, candidateAnswers)
+	}
+
+	cmp := buildComparison(candidates, answers, config.scoring)
+	if len(cmp.questions) == 0 {
+		printWithColorln("None of the candidates has answers.", yellow, config)
+		return nil
+	}
+	printComparison(os.Stdout, cmp)
+	return nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_buildComparison(t *testing.T) {
+	candidates := []CandidateView{{ID: 1, Name: "Leo"}, {ID: 2, Name: "Ana"}}
+	answers := [][]AnswerView{
+		{
+			{QuestionID: 10, Question: "What is a JVM?", Result: int(OK), Topic: "java", Level: AssociateOrProgrammer, Title: "Programmer"},
+			{QuestionID: 20, Question: "What is a join?", Result: int(Wrong), Topic: "sql", Level: ProgrammerAnalyst, Title: "Programmer Analyst"},
+		},
+		{
+			{QuestionID: 10, Question: "What is a JVM?", Result: int(Neutral), Topic: "java", Level: AssociateOrProgrammer, Title: "Programmer"},
+		},
+	}
+	cmp := buildComparison(candidates, answers, defaultScoreWeights)
+
+	if len(cmp.questions) != 2 || cmp.questions[0].id != 10 || cmp.questions[1].id != 20 {
+		t.Fatalf("got=[%+v]", cmp.questions)
+	}
+	if !cmp.questions[0].askedToAll() || cmp.questions[1].askedToAll() {
+		t.Errorf("only Q10 was asked to both candidates")
+	}
+	if cmp.questions[1].results[1] != 0 {
+		t.Errorf("Q20 was not asked to Ana, got=[%s]", cmp.questions[1].results[1])
+	}
+	if cmp.totals[0] != (score{points: 1, max: 3, answered: 2}) || cmp.totals[1] != (score{points: 0.5, max: 1, answered: 1}) {
+		t.Errorf("got totals=[%+v]", cmp.totals)
+	}
+	if !EqualTopics(cmp.topics, []string{"java", "sql"}) || cmp.byTopic["sql"][1].answered != 0 {
+		t.Errorf("got topics=[%v] sql=[%+v]", cmp.topics, cmp.byTopic["sql"])
+	}
+
+	var out bytes.Buffer
+	printComparison(&out, cmp)
+	for _, want := range []string{"#1 Leo", "*Q20 sql pa: What is a join?", "WEIGHTED TOTAL", "1 of 2 questions were not asked"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("missing [%s] in:\n%s", want, out.String())
+		}
+	}
+}
+
+func Test_shortQuestion(t *testing.T) {
+	if got := shortQuestion("What is a JVM?"); got != "What is a JVM?" {
+		t.Errorf("got=[%s]", got)
+	}
+	long := strings.Repeat("é", maxComparedQuestionLength+1)
+	want := strings.Repeat("é", maxComparedQuestionLength-3) + "..."
+	if got := shortQuestion(long); got != want {
+		t.Errorf("got=[%s], want=[%s]", got, want)
+	}
+}
+
+func Test_buildComparison_commentedOnly(t *testing.T) {
+	candidates := []CandidateView{{ID: 1, Name: "Leo"}, {ID: 2, Name: "Ana"}}
+	answers := [][]AnswerView{
+		{{QuestionID: 20, Question: "What is a join?", Result: int(OK), Topic: "sql", Level: ProgrammerAnalyst}},
+		{{QuestionID: 20, Question: "What is a join?", Result: int(NotAnsweredYet), Topic: "sql", Level: ProgrammerAnalyst}},
+	}
+	cmp := buildComparison(candidates, answers, defaultScoreWeights)
+	if q := cmp.questions[0]; q.askedBy != 1 || q.askedToAll() {
+		t.Errorf("Q20 was only commented for Ana, got askedBy=[%d]", q.askedBy)
+	}
+}
+
+func Test_compareCandidates_invalidID(t *testing.T) {
+	config := NewConfig()
+	if err := compareCandidates([]string{"x", "2"}, &config, nil); err == nil {
+		t.Errorf("x is not a candidate #, want error")
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chzyer/readline"
+)
+
+// lineReader reads what the interviewer types, both the commands typed at the
+// prompt and the values asked for by the commands themselves.
+type lineReader interface {
+	// readCommand reads a line typed at the REPL prompt, the line is kept in the history.
+	readCommand(prompt string) (string, error)
+	// readLine reads a value asked by a command, e.g. the interviewee's name.
+	readLine(prompt string) (string, error)
+	// interactive tells if there is someone typing, so editors can be opened.
+	interactive() bool
+	close() error
+}
+
+// readlineConsole is the lineReader used when a terminal is attached, it provides
+// line editing, a history saved across sessions, Ctrl-R search and tab completion.
+type readlineConsole struct {
+	rl *readline.Instance
+}
+
+func newConsole(config *Config, db *sql.DB) (lineReader, error) {
+	rl, err := readline.NewEx(&readline.Config{
+		HistoryFile:       filepath.Join(os.Getenv("HOME"), historyFileName),
+		HistorySearchFold: true,
+		AutoComplete:      &commandCompleter{registry: commands, config: config, db: db},
+		InterruptPrompt:   "^C",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &readlineConsole{rl: rl}, nil
+}
+
+func (c *readlineConsole) readCommand(prompt string) (string, error) {
+	c.rl.SetPrompt(prompt)
+	return c.rl.Readline()
+}
+
+func (c *readlineConsole) readLine(prompt string) (string, error) {
+	c.rl.HistoryDisable()
+	defer c.rl.HistoryEnable()
+	c.rl.SetPrompt(prompt)
+	return c.rl.Readline()
+}
+
+func (c *readlineConsole) interactive() bool {
+	return true
+}
+
+func (c *readlineConsole) close() error {
+	return c.rl.Close()
+}
+
+// commandCompleter completes command names and, once a command has been typed,
+// its first argument using the command's own completion.
+type commandCompleter struct {
+	registry *commandRegistry
+	config   *Config
+	db       *sql.DB
+}
+
+func (c *commandCompleter) Do(line []rune, pos int) ([][]rune, int) {
+	typed := string(line[:pos])
+	fields := words(typed)
+	endsInSpace := len(typed) > 0 && strings.HasSuffix(typed, " ")
+
+	var word string
+	var options []string
+	switch {
+	case len(fields) == 0 || (len(fields) == 1 && !endsInSpace):
+		if len(fields) == 1 {
+			word = fields[0]
+		}
+		options = c.registry.names()
+	case (len(fields) == 1 && endsInSpace) || (len(fields) == 2 && !endsInSpace):
+		spec, ok := c.registry.lookup(fields[0])
+		if !ok || spec.complete == nil {
+			return nil, 0
+		}
+		if len(fields) == 2 {
+			word = fields[1]
+		}
+		options = spec.complete(c.config, c.db)
+	default:
+		return nil, 0
+	}
+
+	matches := filterByPrefix(word, options)
+	candidates := make([][]rune, 0, len(matches))
+	for _, match := range matches {
+		candidates = append(candidates, []rune(match[len(word):]+" "))
+	}
+	return candidates, len([]rune(word))
+}
+
+func filterByPrefix(prefix string, options []string) []string {
+	matches := make([]string, 0)
+	for _, option := range options {
+		if strings.HasPrefix(option, prefix) {
+			matches = append(matches, option)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+func completeTopics(config *Config, db *sql.DB) []string {
+	topics, err := getTopicsWithQuestions(db)
+	if err != nil {
+		return []string{}
+	}
+	return topics
+}
+
+func completeTopicActions(config *Config, db *sql.DB) []string {
+	actions := make([]string, 0, len(topicActions))
+	for action := range topicActions {
+		actions = append(actions, action)
+	}
+	return actions
+}
+
+func completeThemes(config *Config, db *sql.DB) []string {
+	return themeNames()
+}
+
+func completeCandidateIDs(config *Config, db *sql.DB) []string {
+	candidates, err := getCandidates(db)
+	if err != nil {
+		return []string{}
+	}
+	ids := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		ids = append(ids, strconv.Itoa(candidate.ID))
+	}
+	return ids
+}
+
+func completeQuestionIDs(config *Config, db *sql.DB) []string {
+	questions := config.interview.Topics[config.selectedTopic]
+	ids := make([]string, 0, len(questions))
+	for _, q := range questions {
+		ids = append(ids, strconv.Itoa(q.ID))
+	}
+	return ids
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func Test_filterByPrefix(t *testing.T) {
+	type test struct {
+		prefix  string
+		options []string
+		want    []string
+	}
+
+	tests := []test{
+		{prefix: "s", options: []string{"sql", "java", "spring"}, want: []string{"spring", "sql"}},
+		{prefix: "", options: []string{"sql", "java"}, want: []string{"java", "sql"}},
+		{prefix: "x", options: []string{"sql", "java"}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		if got := filterByPrefix(tt.prefix, tt.options); !EqualTopics(got, tt.want) {
+			t.Errorf("got=[%v], want=[%v]", got, tt.want)
+		}
+	}
+}
+
+func Test_commandCompleter_Do(t *testing.T) {
+	registry := &commandRegistry{byName: make(map[string]*commandSpec)}
+	registry.register(&commandSpec{names: []string{"use", "u"}, minArgs: 1,
+		complete: func(config *Config, db *sql.DB) []string { return []string{"java", "sql", "spring"} }})
+	registry.register(&commandSpec{names: []string{"start", "stats"}})
+
+	completer := &commandCompleter{registry: registry}
+
+	type test struct {
+		line   string
+		want   []string
+		length int
+	}
+
+	tests := []test{
+		{line: "st", want: []string{"art ", "ats "}, length: 2},
+		{line: "use s", want: []string{"pring ", "ql "}, length: 1},
+		{line: "use ", want: []string{"java ", "spring ", "sql "}, length: 0},
+		{line: "start ", want: []string{}, length: 0},
+		{line: "use java x", want: []string{}, length: 0},
+	}
+
+	for _, tt := range tests {
+		got, length := completer.Do([]rune(tt.line), len(tt.line))
+		completions := make([]string, 0, len(got))
+		for _, c := range got {
+			completions = append(completions, string(c))
+		}
+		if !EqualTopics(completions, tt.want) || length != tt.length {
+			t.Errorf("line=[%s], got=[%v, %d], want=[%v, %d]", tt.line, completions, length, tt.want, tt.length)
+		}
+	}
+}
@@ -1,18 +1,34 @@
 package main
 
+import "time"
+
+// Color keys, the colors themselves come from the theme:
 const (
-	red     = "#E88388"
-	green   = "#A8CC8C"
-	yellow  = "#DBAB79"
-	blue    = "#71BEF2"
-	magenta = "#D290E4"
-	cyan    = "#66C2CD"
-	gray    = "#B9BFCA"
+	red     = "red"
+	green   = "green"
+	yellow  = "yellow"
+	blue    = "blue"
+	magenta = "magenta"
+	cyan    = "cyan"
+	gray    = "gray"
 )
 
 const (
 	minNumberOfCharsInIntervieweeName = 10
 	interviewFormatLayout             = "2006-01-2 15:04:05"
+	historyFileName                   = ".interview_history"
+	errorLogFileName                  = ".interview.log"
+	settingsFileName                  = "interview"
+	sessionFileName                   = ".interview_session.json"
+	maxCommentLength                  = 1000
+	maxUndoEntries                    = 100
+)
+
+const (
+	dbRetryAttempts      = 3
+	dbRetryBackoff       = 200 * time.Millisecond
+	mysqlLockWaitTimeout = 1205
+	mysqlDeadlock        = 1213
 )
 
 const (
@@ -30,6 +46,12 @@ const (
 	requiredNumberOfFieldsInInterviewHeaderRecord = 2
 )
 
+// Interview events:
+const (
+	levelChangeEvent = "level-change"
+	regradeEvent     = "regrade"
+)
+
 const (
 	// AssociateOrProgrammer ...
 	AssociateOrProgrammer Level = 1
@@ -40,39 +62,3 @@ const (
 	// All ...
 	All Level = 4
 )
-
-// Commands:
-const (
-	exitCmd                        Command = iota
-	topicsCmd                      Command = iota
-	helpCmd                        Command = iota
-	useCmd                         Command = iota
-	clearScreenCmd                 Command = iota
-	pwdCmd                         Command = iota
-	noCmd                          Command = iota
-	startCmd                       Command = iota
-	printCmd                       Command = iota
-	nextQuestionCmd                Command = iota
-	previousQuestionCmd            Command = iota
-	viewCmd                        Command = iota
-	rightAnswerCmd                 Command = iota
-	wrongAnswerCmd                 Command = iota
-	mehAnswerCmd                   Command = iota
-	finishCmd                      Command = iota
-	increaseLevelCmd               Command = iota
-	decreaseLevelCmd               Command = iota
-	ignoreLevelCmd                 Command = iota
-	showLevelCmd                   Command = iota
-	showStatsCmd                   Command = iota
-	setAssociateProgrammerLevelCmd Command = iota
-	setProgrammerAnalystLevelCmd   Command = iota
-	setSRProgrammerLevelCmd        Command = iota
-	validateQuestionsCmd           Command = iota
-	countCmd                       Command = iota
-	createCommentCmd               Command = iota
-	createQuestionCmd              Command = iota
-	viewCurrentQuestionAnwswerCmd  Command = iota
-	viewAnswersCmd                 Command = iota
-	listCandidatesCmd              Command = iota
-	exploreInterviewCmd            Command = iota
-)
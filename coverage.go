@@ -0,0 +1,136 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// minQuestionsPerLevel is how many questions a topic should have at each level so an
+// interview doesn't run out of them.
+const minQuestionsPerLevel = 3
+
+// coverageCell is the state of the questions of a topic at a level.
+type coverageCell struct {
+	questions    int
+	asked        int
+	emptyAnswers int
+	lastAsked    string
+}
+
+// notes tells where the cell needs questions, answers or use.
+func (c coverageCell) notes() string {
+	if c.questions == 0 {
+		return "no questions"
+	}
+	notes := make([]string, 0)
+	if c.questions < minQuestionsPerLevel {
+		notes = append(notes, fmt.Sprintf("only %d", c.questions))
+	}
+	if never := c.questions - c.asked; never > 0 {
+		notes = append(notes, fmt.Sprintf("%d never asked", never))
+	}
+	if c.emptyAnswers > 0 {
+		notes = append(notes, fmt.Sprintf("%d without answer", c.emptyAnswers))
+	}
+	return strings.Join(notes, ", ")
+}
+
+// coverage is the question bank by topic and level, every topic has a cell per level.
+type coverage struct {
+	topics []string
+	cells  map[string]map[Level]*coverageCell
+}
+
+func buildCoverage(topics []Topic, usage []QuestionUsage) coverage {
+	cov := coverage{cells: make(map[string]map[Level]*coverageCell)}
+	addTopic := func(topic string) {
+		if _, ok := cov.cells[topic]; ok {
+			return
+		}
+		cov.topics = append(cov.topics, topic)
+		cov.cells[topic] = make(map[Level]*coverageCell)
+		for _, level := range []Level{AssociateOrProgrammer, ProgrammerAnalyst, SrProgrammer} {
+			cov.cells[topic][level] = &coverageCell{}
+		}
+	}
+	for _, topic := range topics {
+		addTopic(topic.Topic)
+	}
+
+	for _, u := range usage {
+		addTopic(u.Topic)
+		cell := cov.cells[u.Topic][u.Level]
+		if cell == nil {
+			continue
+		}
+		cell.questions++
+		if u.Asked > 0 {
+			cell.asked++
+		}
+		if u.EmptyAnswer {
+			cell.emptyAnswers++
+		}
+		if u.LastAsked.Valid && u.LastAsked.String > cell.lastAsked {
+			cell.lastAsked = u.LastAsked.String
+		}
+	}
+	sort.Strings(cov.topics)
+	return cov
+}
+
+func printCoverage(out io.Writer, cov coverage) {
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TOPIC\tLEVEL\tQUESTIONS\tASKED\tLAST ASKED\tNO ANSWER\tNOTES")
+	needy := 0
+	for _, topic := range cov.topics {
+		for _, level := range []Level{AssociateOrProgrammer, ProgrammerAnalyst, SrProgrammer} {
+			cell := cov.cells[topic][level]
+			lastAsked := cell.lastAsked
+			if len(lastAsked) == 0 {
+				lastAsked = "never"
+			}
+			notes := cell.notes()
+			if len(notes) > 0 {
+				needy++
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%s\t%d\t%s\n", topic, levelShortNames[level], cell.questions, cell.asked,
+				lastAsked, cell.emptyAnswers, notes)
+		}
+	}
+	tw.Flush()
+	fmt.Fprintf(out, "\n%d of %d topic levels need work, a level should have at least %d questions.\n",
+		needy, len(cov.topics)*3, minQuestionsPerLevel)
+}
+
+// questionCoverage is coverage: the questions of each topic and level, how many were
+// ever asked and when, and how many lack a reference answer.
+func questionCoverage(args []string, config *Config, db *sql.DB) error {
+	topics, err := getTopics(db)
+	if err != nil {
+		return err
+	}
+	usage, err := getQuestionUsage(db)
+	if err != nil {
+		return err
+	}
+
+	cov := buildCoverage(topics, usage)
+	if len(args) > 0 {
+		topic := strings.ToLower(args[0])
+		if _, ok := cov.cells[topic]; !ok {
+			return newCommandError("topic '%s' not found", args[0])
+		}
+		cov.topics = []string{topic}
+	}
+	if len(cov.topics) == 0 {
+		printWithColorln("There are no topics.", yellow, config)
+		return nil
+	}
+	printCoverage(os.Stdout, cov)
+	return nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"strings"
+	"testing"
+)
+
+func Test_buildCoverage(t *testing.T) {
+	topics := []Topic{{ID: 1, Topic: "java"}, {ID: 2, Topic: "bash"}}
+	asked := func(date string) sql.NullString { return sql.NullString{String: date, Valid: true} }
+	usage := []QuestionUsage{
+		{ID: 1, Topic: "java", Level: AssociateOrProgrammer, Asked: 3, LastAsked: asked("2020-06-20 10:00:00")},
+		{ID: 2, Topic: "java", Level: AssociateOrProgrammer, Asked: 1, LastAsked: asked("2020-06-26 10:00:00")},
+		{ID: 3, Topic: "java", Level: AssociateOrProgrammer, EmptyAnswer: true},
+		{ID: 4, Topic: "java", Level: SrProgrammer},
+	}
+	cov := buildCoverage(topics, usage)
+
+	if !EqualTopics(cov.topics, []string{"bash", "java"}) {
+		t.Errorf("got topics=[%v]", cov.topics)
+	}
+	ap := *cov.cells["java"][AssociateOrProgrammer]
+	if ap != (coverageCell{questions: 3, asked: 2, emptyAnswers: 1, lastAsked: "2020-06-26 10:00:00"}) {
+		t.Errorf("got=[%+v]", ap)
+	}
+	if got := ap.notes(); got != "1 never asked, 1 without answer" {
+		t.Errorf("got notes=[%s]", got)
+	}
+	if got := cov.cells["java"][SrProgrammer].notes(); got != "only 1, 1 never asked" {
+		t.Errorf("got notes=[%s]", got)
+	}
+	if got := cov.cells["bash"][ProgrammerAnalyst].notes(); got != "no questions" {
+		t.Errorf("got notes=[%s]", got)
+	}
+
+	var out bytes.Buffer
+	printCoverage(&out, cov)
+	for _, want := range []string{"java   ap", "2020-06-26 10:00:00", "never", "6 of 6 topic levels need work"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("missing [%s] in:\n%s", want, out.String())
+		}
+	}
+}
@@ -6,7 +6,7 @@ import (
 
 func getTopics(db *sql.DB) ([]Topic, error) {
 	var topics []Topic
-	results, err := db.Query("SELECT * FROM topic")
+	results, err := dbQuery(db, "select id, topic, description from topic order by id")
 	if err != nil {
 		return []Topic{}, err
 	}
@@ -14,10 +14,12 @@ func getTopics(db *sql.DB) ([]Topic, error) {
 
 	for results.Next() {
 		var topic Topic
-		err = results.Scan(&topic.ID, &topic.Topic)
+		var description sql.NullString
+		err = results.Scan(&topic.ID, &topic.Topic, &description)
 		if err != nil {
 			return []Topic{}, err
 		}
+		topic.Description = description.String
 		topics = append(topics, topic)
 	}
 
@@ -29,25 +31,17 @@ func saveAnswer(question *Question, result Result, config *Config, db *sql.DB) e
 	intervieweeID := config.intervieweeID
 	exists, err := existsAnswer(intervieweeID, question.ID, db)
 	if err != nil {
-		panic(err)
+		return err
 	}
 	if exists {
 		if err := updateAnswer(question, result, config, db); err != nil {
 			return err
 		}
 	} else {
-		var stmt *sql.Rows
-		if len(config.comment) == 0 {
-			stmt, err = db.Query(`insert into answer (result, question_id, candidate_id) values(?, ?, ?)`,
-				result, question.ID, intervieweeID)
-		} else {
-			stmt, err = db.Query(`insert into answer (result, comment, question_id, candidate_id) values(?, ?, ?, ?)`,
-				result, config.comment, question.ID, intervieweeID)
-		}
-		if err != nil {
+		if _, err := dbInsert(db, `insert into answer (result, question_id, candidate_id) values(?, ?, ?)`,
+			result, question.ID, intervieweeID); err != nil {
 			return err
 		}
-		defer stmt.Close()
 	}
 
 	return nil
@@ -55,21 +49,94 @@ func saveAnswer(question *Question, result Result, config *Config, db *sql.DB) e
 
 func updateAnswer(q *Question, result Result, config *Config, db *sql.DB) error {
 	intervieweeID := config.intervieweeID
-	comment := config.comment
 	if _, err :=
-		db.Exec(`update answer set result = ?, comment = ? where question_id = ? and candidate_id = ?`,
-			result, comment, q.ID, intervieweeID); err != nil {
+		dbExec(db, `update answer set result = ? where question_id = ? and candidate_id = ?`,
+			result, q.ID, intervieweeID); err != nil {
 		return err
 	}
 	return nil
 }
 
+func getAnswerComment(candidateID, questionID int, db *sql.DB) (string, error) {
+	results, err :=
+		dbQuery(db, `select comment from answer where candidate_id = ? and question_id = ?`, candidateID, questionID)
+	if err != nil {
+		return "", err
+	}
+	defer results.Close()
+
+	var comment sql.NullString
+	if results.Next() {
+		if err = results.Scan(&comment); err != nil {
+			return "", err
+		}
+	}
+
+	return comment.String, results.Err()
+}
+
+// getAnswer returns the persisted answer of a question, exists is false when there is none.
+func getAnswer(candidateID, questionID int, db *sql.DB) (exists bool, result Result, comment sql.NullString, err error) {
+	results, err :=
+		dbQuery(db, `select result, comment from answer where candidate_id = ? and question_id = ?`, candidateID, questionID)
+	if err != nil {
+		return false, 0, comment, err
+	}
+	defer results.Close()
+
+	if results.Next() {
+		if err = results.Scan(&result, &comment); err != nil {
+			return false, 0, comment, err
+		}
+		exists = true
+	}
+	return exists, result, comment, results.Err()
+}
+
+// restoreAnswer writes back a previous answer, result and comment alike.
+func restoreAnswer(candidateID, questionID int, result Result, comment sql.NullString, db *sql.DB) error {
+	exists, err := existsAnswer(candidateID, questionID, db)
+	if err != nil {
+		return err
+	}
+	if exists {
+		_, err = dbExec(db, `update answer set result = ?, comment = ? where question_id = ? and candidate_id = ?`,
+			result, comment, questionID, candidateID)
+		return err
+	}
+	_, err = dbInsert(db, `insert into answer (result, comment, question_id, candidate_id) values(?, ?, ?, ?)`,
+		result, comment, questionID, candidateID)
+	return err
+}
+
+func deleteAnswer(candidateID, questionID int, db *sql.DB) error {
+	_, err := dbExec(db, `delete from answer where candidate_id = ? and question_id = ?`, candidateID, questionID)
+	return err
+}
+
+// saveComment stores the comment of an answer, the answer is created as not answered
+// yet when the question hasn't been marked.
+func saveComment(candidateID, questionID int, comment string, db *sql.DB) error {
+	exists, err := existsAnswer(candidateID, questionID, db)
+	if err != nil {
+		return err
+	}
+	if exists {
+		_, err = dbExec(db, `update answer set comment = ? where question_id = ? and candidate_id = ?`,
+			comment, questionID, candidateID)
+		return err
+	}
+	_, err = dbInsert(db, `insert into answer (result, comment, question_id, candidate_id) values(?, ?, ?, ?)`,
+		NotAnsweredYet, comment, questionID, candidateID)
+	return err
+}
+
 func getQuestionsByTopic(topic string, db *sql.DB) ([]Question, error) {
 	questionsPerTopic := make([]Question, 0)
 
 	results, err :=
-		db.Query(
-			`select q.id, question, answer, q.level_id from question q, topic t where t.topic = ? and t.id = q.topic_id`,
+		dbQuery(db,
+			`select q.id, question, answer, q.level_id from question q, topic t where t.topic = ? and t.id = q.topic_id and q.retired = 0`,
 			topic)
 	if err != nil {
 		return []Question{}, err
@@ -91,8 +158,8 @@ func getQuestionsByTopicWithLevel(topic string, level Level, db *sql.DB) ([]Ques
 	questionsPerTopic := make([]Question, 0)
 
 	results, err :=
-		db.Query(
-			`select q.id, question, q.level_id from question q, topic t where t.topic = ? and t.id = q.topic_id and level_id = ?`,
+		dbQuery(db,
+			`select q.id, question, q.level_id from question q, topic t where t.topic = ? and t.id = q.topic_id and level_id = ? and q.retired = 0`,
 			topic, level)
 	if err != nil {
 		return []Question{}, err
@@ -113,7 +180,7 @@ func getQuestionsByTopicWithLevel(topic string, level Level, db *sql.DB) ([]Ques
 
 func getTopicsWithQuestions(db *sql.DB) ([]string, error) {
 	var topics []string
-	results, err := db.Query("select distinct(t.topic) from topic t inner join question q on t.id = q.topic_id")
+	results, err := dbQuery(db, "select distinct(t.topic) from topic t inner join question q on t.id = q.topic_id and q.retired = 0")
 	if err != nil {
 		return []string{}, err
 	}
@@ -131,7 +198,7 @@ func getTopicsWithQuestions(db *sql.DB) ([]string, error) {
 }
 
 func saveIntervieweeName(interviewee string, db *sql.DB) (int, error) {
-	stmt, err := db.Exec("insert into candidate(name, date) values(?, now())", interviewee)
+	stmt, err := dbInsert(db, "insert into candidate(name, date) values(?, now())", interviewee)
 	if err != nil {
 		return -1, err
 	}
@@ -142,9 +209,18 @@ func saveIntervieweeName(interviewee string, db *sql.DB) (int, error) {
 	return int(id), nil
 }
 
+// saveQuestionSampling records how the questions of the interview were sampled, a null
+// seed means they were asked in database order.
+func saveQuestionSampling(candidateID int, config *Config, db *sql.DB) error {
+	seed := sql.NullInt64{Int64: config.seed, Valid: config.shuffle}
+	draw := sql.NullInt64{Int64: int64(config.drawCount), Valid: config.shuffle && config.drawCount > 0}
+	_, err := dbExec(db, "update candidate set question_seed = ?, question_draw = ? where id = ?", seed, draw, candidateID)
+	return err
+}
+
 func existsAnswer(candidateID, questionID int, db *sql.DB) (bool, error) {
 	results, err :=
-		db.Query(`select count(*) from answer where candidate_id = ? and question_id = ?`, candidateID, questionID)
+		dbQuery(db, `select count(*) from answer where candidate_id = ? and question_id = ?`, candidateID, questionID)
 	if err != nil {
 		return false, err
 	}
@@ -161,7 +237,7 @@ func existsAnswer(candidateID, questionID int, db *sql.DB) (bool, error) {
 }
 
 func saveQuestion(q *Question, topicID int, answer string, db *sql.DB) error {
-	_, err := db.Exec(`insert into question (question, answer, topic_id, level_id) values(?, ?, ?, ?)`, q.Q, answer, topicID, q.Level)
+	_, err := dbInsert(db, `insert into question (question, answer, topic_id, level_id) values(?, ?, ?, ?)`, q.Q, answer, topicID, q.Level)
 	if err != nil {
 		return err
 	}
@@ -171,9 +247,128 @@ func saveQuestion(q *Question, topicID int, answer string, db *sql.DB) error {
 	return nil
 }
 
+// getQuestion returns a question with its topic, retired ones included.
+func getQuestion(id int, db *sql.DB) (Question, Topic, bool, error) {
+	results, err := dbQuery(db, `select q.id, q.question, q.answer, q.level_id, t.id, t.topic, q.retired
+	from question q inner join topic t on t.id = q.topic_id
+	where q.id = ?`, id)
+	if err != nil {
+		return Question{}, Topic{}, false, err
+	}
+	defer results.Close()
+
+	if !results.Next() {
+		if err = results.Err(); err != nil {
+			return Question{}, Topic{}, false, err
+		}
+		return Question{}, Topic{}, false, newCommandError("Q%d not found", id)
+	}
+	var q Question
+	var topic Topic
+	var answer sql.NullString
+	var retired bool
+	if err = results.Scan(&q.ID, &q.Q, &answer, &q.Level, &topic.ID, &topic.Topic, &retired); err != nil {
+		return Question{}, Topic{}, false, err
+	}
+	q.Answer = answer.String
+	return q, topic, retired, nil
+}
+
+func updateQuestion(q *Question, topicID int, db *sql.DB) error {
+	_, err := dbExec(db, `update question set question = ?, answer = ?, topic_id = ?, level_id = ? where id = ?`,
+		q.Q, q.Answer, topicID, q.Level, q.ID)
+	return err
+}
+
+// getQuestionUsage returns how much every question that is not retired has been asked.
+func getQuestionUsage(db *sql.DB) ([]QuestionUsage, error) {
+	results, err := dbQuery(db, `select q.id, t.topic, q.level_id
+	, q.answer is null or trim(q.answer) = ''
+	, count(a.id)
+	, max(c.date)
+from question q
+inner join topic t
+	on t.id = q.topic_id
+left join (answer a
+	inner join candidate c
+	on c.id = a.candidate_id and c.deleted = 0)
+	on a.question_id = q.id and a.result <> ?
+where q.retired = 0
+group by q.id, t.topic, q.level_id, q.answer`, NotAnsweredYet)
+	if err != nil {
+		return []QuestionUsage{}, err
+	}
+	defer results.Close()
+
+	usage := make([]QuestionUsage, 0)
+	for results.Next() {
+		var u QuestionUsage
+		if err = results.Scan(&u.ID, &u.Topic, &u.Level, &u.EmptyAnswer, &u.Asked, &u.LastAsked); err != nil {
+			return []QuestionUsage{}, err
+		}
+		usage = append(usage, u)
+	}
+	return usage, results.Err()
+}
+
+func setQuestionRetired(id int, retired bool, db *sql.DB) error {
+	_, err := dbExec(db, `update question set retired = ? where id = ?`, retired, id)
+	return err
+}
+
+func saveTopic(name, description string, db *sql.DB) error {
+	_, err := dbInsert(db, "insert into topic(topic, description) values(?, ?)", name, nullIfEmpty(description))
+	return err
+}
+
+func updateTopicName(id int, name string, db *sql.DB) error {
+	_, err := dbExec(db, "update topic set topic = ? where id = ?", name, id)
+	return err
+}
+
+func updateTopicDescription(id int, description string, db *sql.DB) error {
+	_, err := dbExec(db, "update topic set description = ? where id = ?", nullIfEmpty(description), id)
+	return err
+}
+
+// getTopicUsage counts the questions of a topic, retired ones included, and their answers.
+func getTopicUsage(id int, db *sql.DB) (questions, answers int, err error) {
+	err = withRetry(func() error {
+		return db.QueryRow(`select count(distinct q.id), count(a.id)
+			from question q left join answer a on a.question_id = q.id
+			where q.topic_id = ?`, id).Scan(&questions, &answers)
+	})
+	return questions, answers, err
+}
+
+// mergeTopics moves the questions of a topic to another one and deletes it, both
+// in the same transaction.
+func mergeTopics(fromID, intoID int, db *sql.DB) error {
+	return withRetry(func() error {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec("update question set topic_id = ? where topic_id = ?", intoID, fromID); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if _, err := tx.Exec("delete from topic where id = ?", fromID); err != nil {
+			tx.Rollback()
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+func removeTopic(id int, db *sql.DB) error {
+	_, err := dbExec(db, "delete from topic where id = ?", id)
+	return err
+}
+
 func getResultCounts(candidateID int, db *sql.DB) ([]ResultCount, error) {
 	results, err :=
-		db.Query(`select result, count(result) count 
+		dbQuery(db, `select result, count(result) count 
 		from answer 
 		where candidate_id = ? 
 		group by result order by result`, candidateID)
@@ -195,14 +390,19 @@ func getResultCounts(candidateID int, db *sql.DB) ([]ResultCount, error) {
 	return counts, nil
 }
 
-func getAnswersFromCandidate(candidateID int, db *sql.DB) ([]AnswerView, error) {
-	query := `
+// answerViewQuery selects the columns scanned by scanAnswerView, the candidate is
+// selected last.
+const answerViewQuery = `
 	select a.id
+	, q.id
 	, q.question
+	, q.answer
 	, a.result
 	, a.comment
 	, t.topic
+	, q.level_id
 	, lvl.title 
+	, a.candidate_id
 from answer a 
 inner join question q 
 	on a.question_id = q.id 
@@ -210,9 +410,19 @@ inner join topic t
 	on t.id = q.topic_id 
 inner join level lvl 
 	on q.level_id = lvl.id 
-where a.candidate_id = ?
-	`
-	results, err := db.Query(query, candidateID)
+`
+
+func scanAnswerView(results *sql.Rows) (AnswerView, int, error) {
+	var av AnswerView
+	var answer sql.NullString
+	var candidateID int
+	err := results.Scan(&av.ID, &av.QuestionID, &av.Question, &answer, &av.Result, &av.Comment, &av.Topic, &av.Level, &av.Title, &candidateID)
+	av.Answer = answer.String
+	return av, candidateID, err
+}
+
+func getAnswersFromCandidate(candidateID int, db *sql.DB) ([]AnswerView, error) {
+	results, err := dbQuery(db, answerViewQuery+"where a.candidate_id = ? order by a.id", candidateID)
 	if err != nil {
 		return []AnswerView{}, err
 	}
@@ -221,8 +431,8 @@ where a.candidate_id = ?
 	ans := make([]AnswerView, 0)
 
 	for results.Next() {
-		var av AnswerView
-		if err = results.Scan(&av.ID, &av.Question, &av.Result, &av.Comment, &av.Topic, &av.Title); err != nil {
+		av, _, err := scanAnswerView(results)
+		if err != nil {
 			return []AnswerView{}, err
 		}
 		ans = append(ans, av)
@@ -231,22 +441,210 @@ where a.candidate_id = ?
 	return ans, nil
 }
 
+// getAnswersByCandidate returns the graded answers of every candidate that has not been
+// deleted.
+func getAnswersByCandidate(db *sql.DB) (map[int][]AnswerView, error) {
+	results, err := dbQuery(db, answerViewQuery+`inner join candidate c
+	on c.id = a.candidate_id
+where c.deleted = 0 and a.result <> ?
+order by a.candidate_id, a.id`, NotAnsweredYet)
+	if err != nil {
+		return map[int][]AnswerView{}, err
+	}
+	defer results.Close()
+
+	answers := make(map[int][]AnswerView)
+	for results.Next() {
+		av, candidateID, err := scanAnswerView(results)
+		if err != nil {
+			return map[int][]AnswerView{}, err
+		}
+		answers[candidateID] = append(answers[candidateID], av)
+	}
+	return answers, results.Err()
+}
+
+// getCandidateAnswers returns the result and comment of every answer of a candidate by question ID.
+func getCandidateAnswers(candidateID int, db *sql.DB) (map[int]Question, error) {
+	results, err := dbQuery(db, `select question_id, result, comment from answer where candidate_id = ?`, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+
+	answers := make(map[int]Question)
+	for results.Next() {
+		var q Question
+		var comment sql.NullString
+		if err = results.Scan(&q.ID, &q.Result, &comment); err != nil {
+			return nil, err
+		}
+		q.Comment = comment.String
+		answers[q.ID] = q
+	}
+
+	return answers, results.Err()
+}
+
+// candidateColumns are the columns scanned by scanCandidate, in order.
+const candidateColumns = "id, name, date, email, position, seniority, source, notes, deleted, question_seed, question_draw"
+
+func scanCandidate(results *sql.Rows) (CandidateView, error) {
+	var candidate CandidateView
+	var email, position, seniority, source, notes sql.NullString
+	err := results.Scan(&candidate.ID, &candidate.Name, &candidate.Date, &email, &position, &seniority, &source, &notes,
+		&candidate.Deleted, &candidate.QuestionSeed, &candidate.QuestionDraw)
+	candidate.Email, candidate.Position, candidate.Seniority = email.String, position.String, seniority.String
+	candidate.Source, candidate.Notes = source.String, notes.String
+	return candidate, err
+}
+
+// getCandidates returns every candidate that has not been deleted.
 func getCandidates(db *sql.DB) ([]CandidateView, error) {
-	var candidates []CandidateView
-	results, err := db.Query("SELECT * FROM candidate")
+	candidates, _, err := findCandidates(candidateFilter{}, db)
+	return candidates, err
+}
+
+// findCandidates returns the candidates that match the filter, a page of them when the
+// filter has one, and how many candidates match in total.
+func findCandidates(filter candidateFilter, db *sql.DB) ([]CandidateView, int, error) {
+	where, args := filter.where()
+	var total int
+	err := withRetry(func() error {
+		return db.QueryRow("select count(*) from candidate where "+where, args...).Scan(&total)
+	})
+	if err != nil {
+		return []CandidateView{}, 0, err
+	}
+
+	q := "select " + candidateColumns + " from candidate where " + where + " order by date, id"
+	if filter.page > 0 {
+		q += " limit ? offset ?"
+		args = append(args, candidatesPerPage, (filter.page-1)*candidatesPerPage)
+	}
+	results, err := dbQuery(db, q, args...)
 	if err != nil {
-		return []CandidateView{}, err
+		return []CandidateView{}, 0, err
 	}
 	defer results.Close()
 
+	candidates := make([]CandidateView, 0)
 	for results.Next() {
-		var candidate CandidateView
-		err = results.Scan(&candidate.ID, &candidate.Name, &candidate.Date)
+		candidate, err := scanCandidate(results)
 		if err != nil {
-			return []CandidateView{}, err
+			return []CandidateView{}, 0, err
 		}
 		candidates = append(candidates, candidate)
 	}
+	return candidates, total, results.Err()
+}
+
+func getCandidate(candidateID int, db *sql.DB) (CandidateView, error) {
+	results, err :=
+		dbQuery(db, "select "+candidateColumns+" from candidate where id = ?", candidateID)
+	if err != nil {
+		return CandidateView{}, err
+	}
+	defer results.Close()
+
+	if !results.Next() {
+		if err = results.Err(); err != nil {
+			return CandidateView{}, err
+		}
+		return CandidateView{}, newCommandError("candidate #%d not found", candidateID)
+	}
+	return scanCandidate(results)
+}
+
+func updateCandidate(candidate *CandidateView, db *sql.DB) error {
+	_, err := dbExec(db, `update candidate
+		set name = ?, email = ?, position = ?, seniority = ?, source = ?, notes = ?
+		where id = ?`,
+		candidate.Name, nullIfEmpty(candidate.Email), nullIfEmpty(candidate.Position), nullIfEmpty(candidate.Seniority),
+		nullIfEmpty(candidate.Source), nullIfEmpty(candidate.Notes), candidate.ID)
+	return err
+}
+
+func setCandidateDeleted(candidateID int, deleted bool, db *sql.DB) error {
+	_, err := dbExec(db, "update candidate set deleted = ? where id = ?", deleted, candidateID)
+	return err
+}
+
+// mergeCandidates moves the answers and the history of a candidate to another one and
+// deletes the first one. When both answered the same question the answer of the
+// candidate kept wins, and the profile fields it lacks are taken from the other one.
+func mergeCandidates(fromID, intoID int, db *sql.DB) error {
+	statements := []struct {
+		q    string
+		args []interface{}
+	}{
+		{q: `delete a from answer a
+			inner join answer kept on kept.question_id = a.question_id and kept.candidate_id = ?
+			where a.candidate_id = ?`, args: []interface{}{intoID, fromID}},
+		{q: "update answer set candidate_id = ? where candidate_id = ?", args: []interface{}{intoID, fromID}},
+		{q: "update interview_event set candidate_id = ? where candidate_id = ?", args: []interface{}{intoID, fromID}},
+		{q: `update candidate c inner join candidate f on f.id = ?
+			set c.email = coalesce(c.email, f.email), c.position = coalesce(c.position, f.position),
+			c.seniority = coalesce(c.seniority, f.seniority), c.source = coalesce(c.source, f.source),
+			c.notes = coalesce(c.notes, f.notes)
+			where c.id = ?`, args: []interface{}{fromID, intoID}},
+		{q: "update candidate set deleted = 1 where id = ?", args: []interface{}{fromID}},
+	}
+	return withRetry(func() error {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		for _, statement := range statements {
+			if _, err := tx.Exec(statement.q, statement.args...); err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+		return tx.Commit()
+	})
+}
+
+func nullIfEmpty(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: len(s) > 0}
+}
+
+func saveInterviewEvent(candidateID int, event, detail string, db *sql.DB) (int, error) {
+	stmt, err := dbInsert(db, "insert into interview_event (candidate_id, event, detail, created_at) values(?, ?, ?, now())",
+		candidateID, event, detail)
+	if err != nil {
+		return -1, err
+	}
+	id, err := stmt.LastInsertId()
+	if err != nil {
+		return -1, err
+	}
+	return int(id), nil
+}
+
+func deleteInterviewEvent(id int, db *sql.DB) error {
+	_, err := dbExec(db, "delete from interview_event where id = ?", id)
+	return err
+}
+
+func getInterviewEvents(candidateID int, db *sql.DB) ([]InterviewEvent, error) {
+	results, err := dbQuery(db,
+		"select event, detail, created_at from interview_event where candidate_id = ? order by created_at, id", candidateID)
+	if err != nil {
+		return []InterviewEvent{}, err
+	}
+	defer results.Close()
+
+	events := make([]InterviewEvent, 0)
+	for results.Next() {
+		var event InterviewEvent
+		var detail sql.NullString
+		if err = results.Scan(&event.Event, &detail, &event.CreatedAt); err != nil {
+			return []InterviewEvent{}, err
+		}
+		event.Detail = detail.String
+		events = append(events, event)
+	}
 
-	return candidates, nil
+	return events, results.Err()
 }
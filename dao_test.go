@@ -197,3 +197,150 @@ func Test_answerAs(t *testing.T) {
 
 }
 */
+
+func Test_restoreAnswer(t *testing.T) {
+	candidateID, err := saveIntervieweeName("Undo test candidate", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	questions, err := getQuestionsByTopic("java", db)
+	if err != nil || len(questions) == 0 {
+		t.Fatalf("expecting java questions in DB: %v", err)
+	}
+	questionID := questions[0].ID
+
+	comment := sql.NullString{String: "knows generics", Valid: true}
+	if err := restoreAnswer(candidateID, questionID, Wrong, comment, db); err != nil {
+		t.Fatal(err)
+	}
+	exists, result, got, err := getAnswer(candidateID, questionID, db)
+	if err != nil || !exists || result != Wrong || got != comment {
+		t.Errorf("got exists=[%t] result=[%s] comment=[%v] error=[%v]", exists, result, got, err)
+	}
+
+	if err := restoreAnswer(candidateID, questionID, OK, sql.NullString{}, db); err != nil {
+		t.Fatal(err)
+	}
+	if exists, result, got, err = getAnswer(candidateID, questionID, db); err != nil || result != OK || got.Valid {
+		t.Errorf("got exists=[%t] result=[%s] comment=[%v] error=[%v]", exists, result, got, err)
+	}
+
+	if err := deleteAnswer(candidateID, questionID, db); err != nil {
+		t.Fatal(err)
+	}
+	if exists, _, _, err = getAnswer(candidateID, questionID, db); err != nil || exists {
+		t.Errorf("got exists=[%t] error=[%v], the answer should be deleted", exists, err)
+	}
+}
+
+func Test_setQuestionRetired(t *testing.T) {
+	questions, err := getQuestionsByTopic("java", db)
+	if err != nil || len(questions) == 0 {
+		t.Fatalf("expecting java questions in DB: %v", err)
+	}
+	id := questions[0].ID
+
+	if err := setQuestionRetired(id, true, db); err != nil {
+		t.Fatal(err)
+	}
+	defer setQuestionRetired(id, false, db)
+
+	if _, _, retired, err := getQuestion(id, db); err != nil || !retired {
+		t.Errorf("got retired=[%t] error=[%v]", retired, err)
+	}
+	loaded, err := getQuestionsByTopic("java", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range loaded {
+		if q.ID == id {
+			t.Errorf("Q%d is retired and it was loaded", id)
+		}
+	}
+}
+
+func Test_mergeTopics(t *testing.T) {
+	if err := saveTopic("test-from", "merged away", db); err != nil {
+		t.Fatal(err)
+	}
+	if err := saveTopic("test-into", "", db); err != nil {
+		t.Fatal(err)
+	}
+	from, err := findTopic("test-from", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	into, err := findTopic("test-into", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeTopic(into.ID, db)
+
+	if from.Description != "merged away" {
+		t.Errorf("got description=[%s]", from.Description)
+	}
+	if err := mergeTopics(from.ID, into.ID, db); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := findTopic("test-from", db); err == nil {
+		t.Errorf("test-from should be deleted after the merge")
+	}
+	if questions, answers, err := getTopicUsage(into.ID, db); err != nil || questions != 0 || answers != 0 {
+		t.Errorf("got questions=[%d] answers=[%d] error=[%v]", questions, answers, err)
+	}
+}
+
+func Test_mergeCandidates(t *testing.T) {
+	fromID, err := saveIntervieweeName("Merge test from", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	intoID, err := saveIntervieweeName("Merge test into", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer setCandidateDeleted(intoID, true, db)
+
+	from, err := getCandidate(fromID, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	from.Email = "merge@example.com"
+	if err := updateCandidate(&from, db); err != nil {
+		t.Fatal(err)
+	}
+	if err := mergeCandidates(fromID, intoID, db); err != nil {
+		t.Fatal(err)
+	}
+
+	if from, err = getCandidate(fromID, db); err != nil || !from.Deleted {
+		t.Errorf("got deleted=[%t] error=[%v]", from.Deleted, err)
+	}
+	into, err := getCandidate(intoID, db)
+	if err != nil || into.Email != "merge@example.com" {
+		t.Errorf("got email=[%s] error=[%v]", into.Email, err)
+	}
+	candidates, _, err := findCandidates(candidateFilter{name: "Merge test"}, db)
+	if err != nil || len(candidates) != 1 || candidates[0].ID != intoID {
+		t.Errorf("got=%v error=[%v]", candidates, err)
+	}
+}
+
+func Test_getQuestionUsage(t *testing.T) {
+	usage, err := getQuestionUsage(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	java := 0
+	for _, u := range usage {
+		if u.Topic == "java" {
+			java++
+		}
+		if u.Asked == 0 && u.LastAsked.Valid {
+			t.Errorf("Q%d was never asked and it has a last-asked date", u.ID)
+		}
+	}
+	if java == 0 {
+		t.Errorf("expecting java questions in DB")
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"time"
+
+	"github.com/chzyer/readline"
+	"github.com/go-sql-driver/mysql"
+)
+
+// commandError is an error caused by how a command was used rather than by a
+// failure, e.g. marking a question before the interview has started.
+type commandError struct {
+	msg string
+}
+
+func (e *commandError) Error() string {
+	return e.msg
+}
+
+func newCommandError(format string, a ...interface{}) error {
+	return &commandError{msg: fmt.Sprintf(format, a...)}
+}
+
+// panicError is a panic raised by a command, it is turned into an error so the
+// interview can go on.
+type panicError struct {
+	value interface{}
+	stack []byte
+}
+
+func (e *panicError) Error() string {
+	return fmt.Sprintf("%v", e.value)
+}
+
+// isTransient tells if a database error is worth retrying: lost connections,
+// deadlocks and lock wait timeouts.
+func isTransient(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, mysql.ErrInvalidConn) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case mysqlLockWaitTimeout, mysqlDeadlock:
+			return true
+		}
+	}
+	return false
+}
+
+// withRetry runs op again when it fails with a transient error. Only reads and
+// idempotent updates can be retried: the server may have committed a statement whose
+// connection was lost afterwards.
+func withRetry(op func() error) error {
+	var err error
+	for attempt := 1; attempt <= dbRetryAttempts; attempt++ {
+		if err = op(); err == nil || !isTransient(err) {
+			return err
+		}
+		time.Sleep(time.Duration(attempt) * dbRetryBackoff)
+	}
+	return err
+}
+
+func dbQuery(db *sql.DB, q string, args ...interface{}) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := withRetry(func() error {
+		var err error
+		rows, err = db.Query(q, args...)
+		return err
+	})
+	return rows, err
+}
+
+// dbExec runs an update or a delete, running it twice has to leave the same rows.
+func dbExec(db *sql.DB, q string, args ...interface{}) (sql.Result, error) {
+	var result sql.Result
+	err := withRetry(func() error {
+		var err error
+		result, err = db.Exec(q, args...)
+		return err
+	})
+	return result, err
+}
+
+// dbInsert runs an insert only once, retrying it after a lost connection could save
+// the row twice. database/sql already retries the statements that never reached the
+// server.
+func dbInsert(db *sql.DB, q string, args ...interface{}) (sql.Result, error) {
+	return db.Exec(q, args...)
+}
+
+// runCommand runs the command's handler, a panic inside the handler is returned
+// as an error.
+func runCommand(spec *commandSpec, args []string, config *Config, db *sql.DB) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &panicError{value: r, stack: debug.Stack()}
+		}
+	}()
+	if spec.undo != noUndo && config.hasStarted {
+		return runUndoable(spec, args, config, db)
+	}
+	if spec.macro {
+		return runMacro(spec, args, config, db)
+	}
+	return spec.handler(args, config, db)
+}
+
+// reportError tells the interviewer what went wrong with a command, the errors that
+// aren't caused by the interviewer are logged to be diagnosed later.
+func reportError(text string, err error, config *Config) {
+	var cmdErr *commandError
+	switch {
+	case errors.As(err, &cmdErr):
+		printWithColorln(err.Error(), red, config)
+	case errors.Is(err, readline.ErrInterrupt):
+		printWithColorln("Cancelled.", yellow, config)
+	case isTransient(err):
+		logError(text, err, config)
+		printWithColorln(fmt.Sprintf("The database is not available right now (%s), try again, the interview is still going on.", err), red, config)
+	default:
+		logError(text, err, config)
+		printWithColorln(fmt.Sprintf("Unexpected error: %s (details in %s), the interview is still going on.", err, errorLogPath()), red, config)
+	}
+}
+
+func logError(text string, err error, config *Config) {
+	if config.errorLog == nil {
+		return
+	}
+	config.errorLog.Printf("command=%q topic=%q candidate=%d level=%d error=%q (%T)",
+		text, config.selectedTopic, config.intervieweeID, config.levelIndex, err, err)
+	var panicErr *panicError
+	if errors.As(err, &panicErr) {
+		config.errorLog.Printf("%s", panicErr.stack)
+	}
+}
+
+func errorLogPath() string {
+	return filepath.Join(os.Getenv("HOME"), errorLogFileName)
+}
+
+func newErrorLog() (*log.Logger, error) {
+	f, err := os.OpenFile(errorLogPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return log.New(f, "", log.LstdFlags), nil
+}
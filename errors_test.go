@@ -0,0 +1,89 @@
+package main
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func Test_isTransient(t *testing.T) {
+	type test struct {
+		err  error
+		want bool
+	}
+
+	tests := []test{
+		{err: driver.ErrBadConn, want: true},
+		{err: fmt.Errorf("saving answer: %w", mysql.ErrInvalidConn), want: true},
+		{err: &mysql.MySQLError{Number: mysqlDeadlock}, want: true},
+		{err: &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}, want: false},
+		{err: newCommandError("Interview has not yet started."), want: false},
+		{err: errors.New("boom"), want: false},
+	}
+
+	for _, tt := range tests {
+		if got := isTransient(tt.err); got != tt.want {
+			t.Errorf("err=[%v], got=[%t], want=[%t]", tt.err, got, tt.want)
+		}
+	}
+}
+
+func Test_withRetry(t *testing.T) {
+	attempts := 0
+	err := withRetry(func() error {
+		attempts++
+		if attempts < 2 {
+			return driver.ErrBadConn
+		}
+		return nil
+	})
+	if err != nil || attempts != 2 {
+		t.Errorf("got=[%v, %d attempts], want=[<nil>, 2 attempts]", err, attempts)
+	}
+
+	attempts = 0
+	boom := errors.New("boom")
+	err = withRetry(func() error {
+		attempts++
+		return boom
+	})
+	if err != boom || attempts != 1 {
+		t.Errorf("got=[%v, %d attempts], want=[%v, 1 attempt]", err, attempts, boom)
+	}
+}
+
+func Test_runCommand(t *testing.T) {
+	spec := &commandSpec{names: []string{"p"},
+		handler: func(args []string, config *Config, db *sql.DB) error {
+			var qs []Question
+			fmt.Println(qs[3])
+			return nil
+		}}
+
+	config := NewConfig()
+	err := runCommand(spec, []string{}, &config, nil)
+	var panicErr *panicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("got=[%v], want a panicError", err)
+	}
+	if len(panicErr.stack) == 0 {
+		t.Error("the stack should have been kept")
+	}
+}
+
+func Test_answerAs_noQuestions(t *testing.T) {
+	config := NewConfig()
+	config.hasStarted = true
+	config.selectedTopic = "bash"
+	config.interview.Topics["bash"] = []Question{{ID: 1, Level: SrProgrammer}}
+
+	err := answerAs(&config, OK, green, nil)
+	var cmdErr *commandError
+	if !errors.As(err, &cmdErr) {
+		t.Errorf("got=[%v], want a command error", err)
+	}
+}
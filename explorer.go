@@ -0,0 +1,264 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// regradeResults are the results an answer can be re-graded to from ei.
+var regradeResults = map[string]Result{"ok": OK, "wrong": Wrong, "meh": Neutral, "neutral": Neutral}
+
+const explorerHelp = `  <n>               opens answer n: question, reference answer and comment
+  ok|wrong|meh [n]  re-grades answer n, or the open one
+  report            prints the full report
+  history           prints what happened during the interview
+  list              prints the summary again
+  back              goes back to the candidates
+  quit              leaves ei`
+
+// explorer browses a previous interview, the answers are numbered from 1 in the order
+// they were given.
+type explorer struct {
+	candidate CandidateView
+	answers   []AnswerView
+	events    []InterviewEvent
+	open      int
+}
+
+func loadExplorer(candidateID int, db *sql.DB) (*explorer, error) {
+	candidate, err := getCandidate(candidateID, db)
+	if err != nil {
+		return nil, err
+	}
+	if candidate.Deleted {
+		return nil, newCommandError("candidate #%d was deleted", candidateID)
+	}
+	answers, err := getAnswersFromCandidate(candidateID, db)
+	if err != nil {
+		return nil, err
+	}
+	events, err := getInterviewEvents(candidateID, db)
+	if err != nil {
+		return nil, err
+	}
+	return &explorer{candidate: candidate, answers: answers, events: events}, nil
+}
+
+// exploreInterview is ei: without a candidate # it asks for one, any text typed instead
+// filters the candidates, and then browses the answers of that candidate.
+func exploreInterview(args []string, config *Config, db *sql.DB) error {
+	if len(args) == 0 && !config.input.interactive() {
+		return newCommandError("pass the candidate #, e.g. ei 7")
+	}
+	filter := candidateFilter{}
+	for {
+		var input string
+		if len(args) > 0 {
+			input, args = strings.Join(args, " "), nil
+		} else {
+			candidates, _, err := findCandidates(filter, db)
+			if err != nil {
+				return err
+			}
+			if len(candidates) == 0 {
+				printWithColorln("No candidates found.", yellow, config)
+			} else {
+				printCandidates(candidates)
+			}
+			fmt.Println()
+			if input, err = config.input.readLine("Candidate # or text to filter (enter to leave)? "); err != nil {
+				return err
+			}
+		}
+
+		input = strings.TrimSpace(input)
+		if len(input) == 0 {
+			return nil
+		}
+		id, err := parseCandidateID(input)
+		if err != nil {
+			if !config.input.interactive() {
+				return err
+			}
+			filter = candidateFilter{text: input}
+			continue
+		}
+
+		e, err := loadExplorer(id, db)
+		if err != nil {
+			return err
+		}
+		e.printSummary(os.Stdout, config)
+		if !config.input.interactive() {
+			return nil
+		}
+		back, err := e.browse(config, db)
+		if err != nil || !back {
+			return err
+		}
+	}
+}
+
+// browse reads the commands of the explorer until the interviewer goes back to the
+// candidates, the result tells if they did.
+func (e *explorer) browse(config *Config, db *sql.DB) (bool, error) {
+	prompt := fmt.Sprintf("ei #%d> ", e.candidate.ID)
+	for {
+		line, err := config.input.readLine(prompt)
+		if err != nil {
+			return false, err
+		}
+		fields := words(strings.ToLower(line))
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "quit", "q", "exit":
+			return false, nil
+		case "back", "b":
+			return true, nil
+		case "list", "l":
+			e.printSummary(os.Stdout, config)
+		case "report":
+			printReport(os.Stdout, buildReport(e.candidate, e.answers))
+		case "history":
+			printEvents(os.Stdout, e.events)
+		case "help", "?":
+			fmt.Println(explorerHelp)
+		default:
+			if err := e.run(fields, config, db); err != nil {
+				reportError(line, err, config)
+			}
+		}
+	}
+}
+
+// run opens or re-grades an answer.
+func (e *explorer) run(fields []string, config *Config, db *sql.DB) error {
+	if result, ok := regradeResults[fields[0]]; ok {
+		n := e.open
+		if len(fields) > 1 {
+			var err error
+			if n, err = e.answerNumber(fields[1]); err != nil {
+				return err
+			}
+		}
+		if n == 0 {
+			return newCommandError("open an answer first or pass its number, e.g. %s 3", fields[0])
+		}
+		return e.regrade(n, result, config, db)
+	}
+
+	n, err := e.answerNumber(fields[0])
+	if err != nil {
+		return newCommandError("unknown command '%s', type help to see the commands", fields[0])
+	}
+	e.open = n
+	printAnswerDetail(os.Stdout, n, e.answers[n-1])
+	return nil
+}
+
+func (e *explorer) answerNumber(arg string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+	if err != nil || n < 1 || n > len(e.answers) {
+		return 0, newCommandError("'%s' is not an answer, they go from 1 to %d", arg, len(e.answers))
+	}
+	return n, nil
+}
+
+// regrade changes the result of an answer keeping its comment, the change is kept in
+// the history of the interview.
+func (e *explorer) regrade(n int, result Result, config *Config, db *sql.DB) error {
+	if err := notRunning(e.candidate.ID, config); err != nil {
+		return err
+	}
+	ans := &e.answers[n-1]
+	previous := Result(ans.Result)
+	if previous == result {
+		return newCommandError("answer %d is already %s", n, result)
+	}
+	if err := restoreAnswer(e.candidate.ID, ans.QuestionID, result, ans.Comment, db); err != nil {
+		return err
+	}
+	detail := fmt.Sprintf("Q%d re-graded from %s to %s", ans.QuestionID, previous, result)
+	if _, err := saveInterviewEvent(e.candidate.ID, regradeEvent, detail, db); err != nil {
+		return err
+	}
+	ans.Result = int(result)
+	e.events = append(e.events, InterviewEvent{Event: regradeEvent, Detail: detail, CreatedAt: time.Now().Format("2006-01-02 15:04:05")})
+	printWithColorln(fmt.Sprintf("Answer %d: %s", n, detail), green, config)
+	return nil
+}
+
+// printSummary prints the candidate, the results by topic and by level, and the numbered
+// answers.
+func (e *explorer) printSummary(w io.Writer, config *Config) {
+	c := e.candidate
+	fmt.Fprintf(w, "#%d %s, interviewed on %s\n", c.ID, c.Name, c.Date)
+	profile := make([]string, 0)
+	for _, field := range []struct{ name, value string }{
+		{"position", c.Position}, {"seniority", c.Seniority}, {"email", c.Email}, {"source", c.Source},
+	} {
+		if len(field.value) > 0 {
+			profile = append(profile, field.name+": "+field.value)
+		}
+	}
+	if len(profile) > 0 {
+		fmt.Fprintln(w, strings.Join(profile, ", "))
+	}
+	if len(c.Notes) > 0 {
+		fmt.Fprintf(w, "Notes: %s\n", c.Notes)
+	}
+	if c.QuestionSeed.Valid {
+		fmt.Fprintf(w, "Questions shuffled, reproduce them with: %s\n",
+			reproduceCommands(c.QuestionSeed.Int64, int(c.QuestionDraw.Int64)))
+	}
+	if len(e.answers) == 0 {
+		fmt.Fprintln(w, "\nNo answers.")
+		return
+	}
+
+	fmt.Fprintln(w)
+	printTallies(w, buildReport(e.candidate, e.answers))
+
+	fmt.Fprintln(w, "\nAnswers:")
+	for i, ans := range e.answers {
+		fmt.Fprintf(w, "%3d) [%s] %s [%s] [%s]\n", i+1, Result(ans.Result), ans.Question, ans.Topic, ans.Title)
+	}
+	if len(e.events) > 0 {
+		fmt.Fprintln(w, "\nHistory:")
+		printEvents(w, e.events)
+	}
+	if config.input.interactive() {
+		fmt.Fprintln(w, "\nType an answer # to open it, or help.")
+	}
+}
+
+func printAnswerDetail(w io.Writer, n int, ans AnswerView) {
+	fmt.Fprintf(w, "Answer %d, Q%d [%s] [%s]: %s\n", n, ans.QuestionID, ans.Topic, ans.Title, Result(ans.Result))
+	fmt.Fprintf(w, "\n%s\n", ans.Question)
+	reference := ans.Answer
+	if len(reference) == 0 {
+		reference = "(no reference answer)"
+	}
+	fmt.Fprintf(w, "\nReference answer:\n%s\n", reference)
+	if ans.Comment.Valid && len(ans.Comment.String) > 0 {
+		fmt.Fprintf(w, "\nComment:\n%s\n", ans.Comment.String)
+	}
+}
+
+func printEvents(w io.Writer, events []InterviewEvent) {
+	if len(events) == 0 {
+		fmt.Fprintln(w, "Nothing happened during the interview.")
+		return
+	}
+	for _, event := range events {
+		fmt.Fprintln(w, event)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"strings"
+	"testing"
+)
+
+func explorerTestData() *explorer {
+	return &explorer{
+		candidate: CandidateView{ID: 7, Name: "Leo", Date: "2020-06-26 10:00:00", Position: "backend"},
+		answers: []AnswerView{
+			{ID: 1, QuestionID: 11, Question: "What is a JVM?", Answer: "The Java virtual machine", Result: int(OK),
+				Comment: sql.NullString{String: "knows it well", Valid: true}, Topic: "java", Title: "Programmer"},
+			{ID: 2, QuestionID: 12, Question: "What is a join?", Result: int(Wrong), Topic: "sql", Title: "Programmer Analyst"},
+		},
+	}
+}
+
+func Test_explorer_printSummary(t *testing.T) {
+	config := NewConfig()
+	config.input = &typedLines{}
+	var out bytes.Buffer
+	explorerTestData().printSummary(&out, &config)
+
+	for _, want := range []string{"#7 Leo", "position: backend", "By topic:", "\tsql: OK: 0", "By level:",
+		"  1) [OK] What is a JVM? [java] [Programmer]", "  2) [Wrong] What is a join?"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("missing [%s] in:\n%s", want, out.String())
+		}
+	}
+}
+
+func Test_printAnswerDetail(t *testing.T) {
+	e := explorerTestData()
+	var out bytes.Buffer
+	printAnswerDetail(&out, 1, e.answers[0])
+	for _, want := range []string{"Answer 1, Q11", "The Java virtual machine", "knows it well"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("missing [%s] in:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	printAnswerDetail(&out, 2, e.answers[1])
+	if !strings.Contains(out.String(), "(no reference answer)") || strings.Contains(out.String(), "Comment:") {
+		t.Errorf("got:\n%s", out.String())
+	}
+}
+
+func Test_explorer_browse(t *testing.T) {
+	config := NewConfig()
+	config.input = &typedLines{lines: []string{"2", "3", "fly", "back"}}
+	e := explorerTestData()
+	back, err := e.browse(&config, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !back || e.open != 2 {
+		t.Errorf("got back=[%t] open=[%d], want back=[true] open=[2]", back, e.open)
+	}
+
+	config.input = &typedLines{lines: []string{"quit"}}
+	if back, err := e.browse(&config, nil); err != nil || back {
+		t.Errorf("got back=[%t] error=[%v]", back, err)
+	}
+}
+
+func Test_explorer_run_regrade(t *testing.T) {
+	config := NewConfig()
+	e := explorerTestData()
+	if err := e.run([]string{"ok"}, &config, nil); err == nil {
+		t.Errorf("there is no open answer, want error")
+	}
+	if err := e.run([]string{"ok", "1"}, &config, nil); err == nil {
+		t.Errorf("answer 1 is already OK, want error")
+	}
+	if err := e.run([]string{"wrong", "9"}, &config, nil); err == nil {
+		t.Errorf("there is no answer 9, want error")
+	}
+
+	config.hasStarted, config.intervieweeID = true, 7
+	if err := e.run([]string{"ok", "2"}, &config, nil); err == nil {
+		t.Errorf("the running interview can't be re-graded")
+	}
+}
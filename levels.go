@@ -2,8 +2,8 @@ package main
 
 import (
 	"fmt"
-
-	"github.com/muesli/termenv"
+	"strconv"
+	"strings"
 )
 
 func increaseLevel(config *Config) {
@@ -66,7 +66,7 @@ func gotoNextQuestion(config *Config) {
 		if (config.questionIndex + 1) < len(config.interview.Topics[config.selectedTopic]) {
 			config.questionIndex++
 		} else {
-			fmt.Println(termenv.String("No questions left ... ").Foreground(config.colorProfile.Color(yellow)))
+			printWithColorln("No questions left ... ", yellow, config)
 		}
 	} else {
 		currentLevel := config.levels[config.levelIndex]
@@ -112,3 +112,69 @@ func getQuestionsFromLevel(lvl Level, config *Config) []Question {
 	}
 	return questions
 }
+
+// currentQuestion returns the question the interview is at, honoring whether levels are being ignored.
+func currentQuestion(config *Config) (Question, bool) {
+	if config.ignoreLevelChecking {
+		questions := config.interview.Topics[config.selectedTopic]
+		if config.questionIndex < 0 || config.questionIndex >= len(questions) {
+			return Question{}, false
+		}
+		return questions[config.questionIndex], true
+	}
+	currentLevel := config.levels[config.levelIndex]
+	currentLevelQuestions := getQuestionsFromLevel(currentLevel, config)
+	index := config.individualLevelIndexes[int(currentLevel)-1]
+	if index < 0 || index >= len(currentLevelQuestions) {
+		return Question{}, false
+	}
+	return currentLevelQuestions[index], true
+}
+
+// findQuestionIndex returns the index in qs of the question referred by target, either
+// its ID (42 or Q42) or its position in the topic (#3).
+func findQuestionIndex(target string, qs []Question) (int, error) {
+	if strings.HasPrefix(target, "#") {
+		position, err := strconv.Atoi(target[1:])
+		if err != nil || position < 1 || position > len(qs) {
+			return -1, newCommandError("position '%s' is not valid, the topic has %d questions", target, len(qs))
+		}
+		return position - 1, nil
+	}
+
+	id, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(target), "Q"))
+	if err != nil {
+		return -1, newCommandError("'%s' is not a question ID nor a #position", target)
+	}
+	for i, q := range qs {
+		if q.ID == id {
+			return i, nil
+		}
+	}
+	return -1, newCommandError("Q%d is not a question of this topic", id)
+}
+
+// moveToQuestion makes the question at index of the topic the current one, the level
+// is switched to the question's level and its cursor is moved to the question.
+func moveToQuestion(index int, config *Config) {
+	q := config.interview.Topics[config.selectedTopic][index]
+	config.questionIndex = index
+
+	config.levelIndex = int(q.Level) - 1
+	for i, lq := range getQuestionsFromLevel(q.Level, config) {
+		if lq.ID == q.ID {
+			config.individualLevelIndexes[int(q.Level)-1] = i
+			break
+		}
+	}
+}
+
+// questionPosition returns the position in the topic of the question with the given ID.
+func questionPosition(id int, config *Config) int {
+	for i, q := range config.interview.Topics[config.selectedTopic] {
+		if q.ID == id {
+			return i + 1
+		}
+	}
+	return 0
+}
@@ -277,3 +277,95 @@ func Test_gotoPreviousQuestion(t *testing.T) {
 	}
 
 }
+
+func Test_currentQuestion(t *testing.T) {
+	topics := make(map[string][]Question)
+	topics["linux"] = []Question{
+		Question{ID: 1, Q: "lx1", Level: AssociateOrProgrammer},
+		Question{ID: 2, Q: "lx2", Level: AssociateOrProgrammer},
+		Question{ID: 3, Q: "lx3", Level: ProgrammerAnalyst},
+	}
+
+	config := NewConfig()
+	config.selectedTopic = "linux"
+	config.interview.Topics = topics
+	config.individualLevelIndexes = []int{1, 0, 0}
+
+	if q, ok := currentQuestion(&config); !ok || q.ID != 2 {
+		t.Errorf("got=[%s, %t], want=[Q2, true]", q, ok)
+	}
+
+	config.levelIndex = int(SrProgrammer) - 1
+	if q, ok := currentQuestion(&config); ok {
+		t.Errorf("got=[%s], there are no questions for this level", q)
+	}
+
+	config.ignoreLevelChecking = true
+	config.questionIndex = 2
+	if q, ok := currentQuestion(&config); !ok || q.ID != 3 {
+		t.Errorf("got=[%s, %t], want=[Q3, true]", q, ok)
+	}
+}
+
+func Test_findQuestionIndex(t *testing.T) {
+	qs := []Question{
+		Question{ID: 40, Level: AssociateOrProgrammer},
+		Question{ID: 41, Level: ProgrammerAnalyst},
+		Question{ID: 42, Level: SrProgrammer},
+	}
+
+	type test struct {
+		target  string
+		want    int
+		wantErr bool
+	}
+
+	tests := []test{
+		{target: "42", want: 2},
+		{target: "Q41", want: 1},
+		{target: "q40", want: 0},
+		{target: "#1", want: 0},
+		{target: "#3", want: 2},
+		{target: "#4", want: -1, wantErr: true},
+		{target: "#0", want: -1, wantErr: true},
+		{target: "7", want: -1, wantErr: true},
+		{target: "abc", want: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := findQuestionIndex(tt.target, qs)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("target=[%s], got=[%d, %v], want=[%d, error=%t]", tt.target, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func Test_moveToQuestion(t *testing.T) {
+	topics := make(map[string][]Question)
+	topics["java"] = []Question{
+		Question{ID: 40, Level: AssociateOrProgrammer},
+		Question{ID: 41, Level: ProgrammerAnalyst},
+		Question{ID: 42, Level: AssociateOrProgrammer},
+		Question{ID: 43, Level: ProgrammerAnalyst},
+	}
+
+	config := NewConfig()
+	config.selectedTopic = "java"
+	config.interview.Topics = topics
+	config.individualLevelIndexes = []int{1, 0, 0}
+
+	moveToQuestion(3, &config)
+
+	if config.levelIndex != int(ProgrammerAnalyst)-1 {
+		t.Errorf("got=[%d], want=[%d]", config.levelIndex, int(ProgrammerAnalyst)-1)
+	}
+	if !EqualNumbers(config.individualLevelIndexes, []int{1, 1, 0}) {
+		t.Errorf("got=[%v], want=[1 1 0]", config.individualLevelIndexes)
+	}
+	if config.questionIndex != 3 {
+		t.Errorf("got=[%d], want=[3]", config.questionIndex)
+	}
+	if q, _ := currentQuestion(&config); q.ID != 43 {
+		t.Errorf("got=[%s], want=[Q43]", q)
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// macroParam matches the positional arguments of a macro step: $1 to $9, and $@ for all of them.
+var macroParam = regexp.MustCompile(`\$([1-9@])`)
+
+// addUserCommands registers the aliases and macros of the settings file, e.g.
+//
+//	aliases:
+//	  k: ok
+//	  w: wrong
+//	macros:
+//	  yn: ok; next
+//	  hard: [sr, print]
+//	  jump: goto $1; print
+func (r *commandRegistry) addUserCommands(v *viper.Viper) error {
+	aliases := v.GetStringMapString("aliases")
+	names := make([]string, 0, len(aliases))
+	for alias := range aliases {
+		names = append(names, alias)
+	}
+	sort.Strings(names)
+	for _, alias := range names {
+		if err := r.addAlias(alias, aliases[alias]); err != nil {
+			return err
+		}
+	}
+
+	macros := v.GetStringMap("macros")
+	names = names[:0]
+	for name := range macros {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var steps []string
+		switch value := macros[name].(type) {
+		case string:
+			steps = splitCommands(value)
+		case []interface{}:
+			for _, step := range value {
+				steps = append(steps, fmt.Sprint(step))
+			}
+		default:
+			return fmt.Errorf("macro '%s': the steps must be a text or a list", name)
+		}
+		if err := r.addMacro(name, steps); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addAlias makes a command available under one more name.
+func (r *commandRegistry) addAlias(alias, target string) error {
+	alias = strings.ToLower(strings.TrimSpace(alias))
+	if _, taken := r.byName[alias]; taken || len(alias) == 0 {
+		return fmt.Errorf("alias '%s': the name is empty or already taken", alias)
+	}
+	spec, ok := r.lookup(target)
+	if !ok {
+		return fmt.Errorf("alias '%s': unknown command '%s'", alias, target)
+	}
+	spec.names = append(spec.names, alias)
+	r.byName[alias] = spec
+	return nil
+}
+
+// addMacro registers a command that runs the given steps in order, stopping at the first
+// one that fails.
+func (r *commandRegistry) addMacro(name string, steps []string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if _, taken := r.byName[name]; taken || len(name) == 0 {
+		return fmt.Errorf("macro '%s': the name is empty or already taken", name)
+	}
+	if len(steps) == 0 {
+		return fmt.Errorf("macro '%s' has no steps", name)
+	}
+
+	params, allArgs := 0, false
+	for _, step := range steps {
+		fields := words(step)
+		if len(fields) == 0 {
+			return fmt.Errorf("macro '%s': empty step", name)
+		}
+		spec, ok := r.lookup(fields[0])
+		if !ok {
+			return fmt.Errorf("macro '%s': unknown command '%s'", name, fields[0])
+		}
+		if spec.macro {
+			return fmt.Errorf("macro '%s': macros can't run other macros ('%s')", name, fields[0])
+		}
+		for _, match := range macroParam.FindAllStringSubmatch(step, -1) {
+			if match[1] == "@" {
+				allArgs = true
+			} else if n, _ := strconv.Atoi(match[1]); n > params {
+				params = n
+			}
+		}
+	}
+
+	spec := &commandSpec{names: []string{name}, macro: true, minArgs: params, maxArgs: params,
+		help: "macro: " + strings.Join(steps, "; "), handler: macroHandler(steps)}
+	if allArgs {
+		spec.maxArgs = unlimitedArgs
+	}
+	usage := make([]string, 0, params)
+	for i := 1; i <= params; i++ {
+		usage = append(usage, fmt.Sprintf("<$%d>", i))
+	}
+	if allArgs {
+		usage = append(usage, "[args...]")
+	}
+	spec.usage = strings.Join(usage, " ")
+	r.register(spec)
+	return nil
+}
+
+// expandMacroStep replaces the positional arguments of a step, arguments with spaces or
+// quotes are quoted so they stay a single argument. The command line has no escapes, so an
+// argument with both kinds of quotes can't be passed on.
+func expandMacroStep(step string, args []string) (string, error) {
+	var err error
+	quote := func(arg string) string {
+		if !strings.ContainsAny(arg, " \t\"'") {
+			return arg
+		}
+		switch {
+		case !strings.Contains(arg, `"`):
+			return `"` + arg + `"`
+		case !strings.Contains(arg, "'"):
+			return "'" + arg + "'"
+		}
+		err = newCommandError("the argument [%s] can't have both kinds of quotes", arg)
+		return arg
+	}
+	expanded := macroParam.ReplaceAllStringFunc(step, func(param string) string {
+		if param == "$@" {
+			quoted := make([]string, len(args))
+			for i, arg := range args {
+				quoted[i] = quote(arg)
+			}
+			return strings.Join(quoted, " ")
+		}
+		n, _ := strconv.Atoi(param[1:])
+		if n > len(args) {
+			return ""
+		}
+		return quote(args[n-1])
+	})
+	return expanded, err
+}
+
+func macroHandler(steps []string) commandHandler {
+	return func(args []string, config *Config, db *sql.DB) error {
+		for _, step := range steps {
+			text, err := expandMacroStep(step, args)
+			if err != nil {
+				return err
+			}
+			spec, stepArgs, err := commands.parse(text)
+			if err == errUnknownCommand {
+				return newCommandError("'%s': unknown command", text)
+			}
+			if err != nil {
+				return newCommandError("'%s': %s", text, err)
+			}
+			if err := runCommand(spec, stepArgs, config, db); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
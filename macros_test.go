@@ -0,0 +1,114 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func Test_commandRegistry_addUserCommands(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	settings := `
+aliases:
+  k: ok
+  fw: next
+macros:
+  twice: next; next
+  jump: [goto $1, print]
+  remark: cmt $@
+`
+	if err := v.ReadConfig(strings.NewReader(settings)); err != nil {
+		t.Fatal(err)
+	}
+	r := newCommandRegistry()
+	if err := r.addUserCommands(v); err != nil {
+		t.Fatal(err)
+	}
+
+	if spec, ok := r.lookup("k"); !ok || spec.name() != "ok" {
+		t.Errorf("alias k: got=[%v]", spec)
+	}
+	if spec, ok := r.lookup("jump"); !ok || spec.minArgs != 1 || spec.maxArgs != 1 || spec.usage != "<$1>" {
+		t.Errorf("macro jump: got=[%+v]", spec)
+	}
+	if spec, ok := r.lookup("remark"); !ok || spec.maxArgs != unlimitedArgs {
+		t.Errorf("macro remark: got=[%+v]", spec)
+	}
+	if _, _, err := r.parse("jump"); err == nil {
+		t.Errorf("jump needs an argument, want error")
+	}
+	if spec, _ := r.lookup("twice"); !strings.Contains(spec.help, "next; next") {
+		t.Errorf("the help of a macro should list its steps, got=[%s]", spec.help)
+	}
+}
+
+func Test_commandRegistry_addUserCommands_invalid(t *testing.T) {
+	invalid := []string{
+		"aliases:\n  y: next\n",
+		"aliases:\n  k: whatever\n",
+		"macros:\n  next: print\n",
+		"macros:\n  m: print; fly\n",
+		"macros:\n  a: next\n  b: a\n",
+	}
+	for _, settings := range invalid {
+		v := viper.New()
+		v.SetConfigType("yaml")
+		if err := v.ReadConfig(strings.NewReader(settings)); err != nil {
+			t.Fatal(err)
+		}
+		if err := newCommandRegistry().addUserCommands(v); err == nil {
+			t.Errorf("settings=[%s]: want error", settings)
+		}
+	}
+}
+
+func Test_expandMacroStep(t *testing.T) {
+	type test struct {
+		step string
+		args []string
+		want string
+	}
+	tests := []test{
+		{step: "goto $1", args: []string{"42"}, want: "goto 42"},
+		{step: "cmt $@", args: []string{"knows", "generics"}, want: "cmt knows generics"},
+		{step: "start $1", args: []string{"Leo Messi"}, want: `start "Leo Messi"`},
+		{step: "cmt $1", args: []string{`says "maybe" a lot`}, want: `cmt 'says "maybe" a lot'`},
+		{step: "cmt $1", args: []string{`"quoted"`}, want: `cmt '"quoted"'`},
+		{step: "goto $2", args: []string{"1"}, want: "goto "},
+		{step: "next", args: []string{}, want: "next"},
+	}
+	for _, tt := range tests {
+		got, err := expandMacroStep(tt.step, tt.args)
+		if err != nil || got != tt.want {
+			t.Errorf("step=[%s] args=%v: got=[%s] error=[%v], want=[%s]", tt.step, tt.args, got, err, tt.want)
+		}
+		if fields := words(got); len(tt.args) == 1 && len(fields) == 2 && fields[1] != tt.args[0] {
+			t.Errorf("step=[%s] args=%v: parsed back as %q", tt.step, tt.args, fields)
+		}
+	}
+
+	if _, err := expandMacroStep("cmt $1", []string{`it's "fine"`}); err == nil {
+		t.Error("an argument with both kinds of quotes should be rejected")
+	}
+}
+
+func Test_macroHandler(t *testing.T) {
+	saved := commands
+	defer func() { commands = saved }()
+	commands = newCommandRegistry()
+	if err := commands.addMacro("skip", []string{"next", "goto $1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	config := undoTestConfig()
+	runTyped(t, "skip 3", &config)
+	if q, _ := currentQuestion(&config); q.ID != 3 {
+		t.Errorf("got question=[%d], want=[3]", q.ID)
+	}
+
+	if err := macroHandler([]string{"goto 99"})(nil, &config, nil); err == nil {
+		t.Errorf("a failing step should fail the macro")
+	}
+}
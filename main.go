@@ -1,18 +1,26 @@
 package main
 
 import (
-	"bufio"
 	"database/sql"
+	"flag"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/chzyer/readline"
 	_ "github.com/go-sql-driver/mysql"
-	"github.com/muesli/termenv"
 )
 
 func main() {
+	script := flag.String("c", "", `runs the given commands separated by ';' and exits, e.g. -c "use java; start Leo; y"`)
+	flag.Usage = func() {
+		printUsage(os.Stderr)
+		fmt.Fprintln(os.Stderr)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
 
 	config := NewConfig()
 
@@ -25,8 +33,36 @@ func main() {
 	if err != nil {
 		panic(fmt.Errorf("fatal error config file: %s", err))
 	}
+
+	settings, err := readSettings(os.Getenv("HOME"))
+	if err != nil {
+		panic(fmt.Errorf("fatal error settings file: %s", err))
+	}
+	config.adaptive = adaptiveRulesFrom(settings)
+	if config.plans, err = plansFrom(settings); err != nil {
+		panic(fmt.Errorf("fatal error settings file: %s", err))
+	}
+	if config.timebox, err = timeboxFrom(settings); err != nil {
+		panic(fmt.Errorf("fatal error settings file: %s", err))
+	}
+	if config.scoring, err = scoringFrom(settings); err != nil {
+		panic(fmt.Errorf("fatal error settings file: %s", err))
+	}
+	if err := commands.addUserCommands(settings); err != nil {
+		panic(fmt.Errorf("fatal error settings file: %s", err))
+	}
+	if name := settings.GetString("theme"); len(name) > 0 {
+		if config.theme, err = findTheme(name); err != nil {
+			panic(fmt.Errorf("fatal error settings file: %s", err))
+		}
+	}
+	config.promptTemplate = settings.GetString("prompt")
+	if err := validatePromptTemplate(config.promptTemplate); err != nil {
+		panic(fmt.Errorf("fatal error settings file: %s", err))
+	}
 	// DB setup ...
 	jdbcURL := fmt.Sprintf("%s:%s@/%s", dbConfig.GetString("db_user"), dbConfig.GetString("db_password"), dbConfig.GetString("db_name"))
+	config.dbName = dbConfig.GetString("db_name")
 	db, err := sql.Open(dbConfig.GetString("db_driver"), jdbcURL)
 	if err != nil {
 		panic(err)
@@ -34,181 +70,82 @@ func main() {
 	db.SetConnMaxLifetime(time.Hour * 3)
 	defer db.Close()
 
-	userInput := bufio.NewReader(os.Stdin)
+	if config.errorLog, err = newErrorLog(); err != nil {
+		fmt.Fprintf(os.Stderr, "interview: errors won't be logged: %s\n", err)
+	}
 
-	for {
-		fmt.Print(ps1String(config.ps1, config.selectedTopic, config.interview.Interviewee))
-		text, _ := userInput.ReadString('\n')
-		text = strings.TrimSpace(text)
-		if len(text) == 0 {
-			continue
+	if flag.NArg() > 0 {
+		err := runSubcommand(flag.Args(), &config, db)
+		if err == flag.ErrHelp {
+			return
 		}
-		cmd, options := userInputToCmd(text)
-
-		switch cmd {
-		case exitCmd:
-			printWithColorln("Bye", magenta, &config)
-			os.Exit(0)
-		case topicsCmd:
-			if err = listTopics(db); err != nil {
-				panic(err)
-			}
-		case helpCmd:
-			printHelp()
-		case clearScreenCmd:
-			clearScreen()
-		case pwdCmd:
-			fmt.Println(termenv.String(config.selectedTopic).Bold())
-		case useCmd:
-			if err = setTopic(options, &config, db); err != nil {
-				panic(err)
-			}
-		case startCmd:
-			if config.hasStarted {
-				printWithColorln("Interview has already started.", yellow, &config)
-				break
-			}
-
-			if len(config.selectedTopic) == 0 {
-				printWithColorln("You need to select a topic first.", red, &config)
-				break
-			}
-
-			fmt.Printf("Interviewee name: ")
-
-			name, ok := readIntervieweeName(os.Stdin)
-			if !ok {
-				break
-			}
-			id, err := saveIntervieweeName(name, db)
-			if err != nil {
-				panic(err)
-			}
-			config.intervieweeID = id
-			config.interview.Interviewee = name
-			config.interview.Date = time.Now()
-			config.hasStarted = true
-			// Message to the user that the interview has started.
-			printQuestion(config.questionIndex, &config)
-		case printCmd:
-			printQuestion(config.questionIndex, &config)
-		case nextQuestionCmd:
-			config.comment = ""
-			gotoNextQuestion(&config)
-			printQuestion(config.questionIndex, &config)
-		case previousQuestionCmd:
-			config.comment = ""
-			gotoPreviousQuestion(&config)
-			printQuestion(config.questionIndex, &config)
-		case viewCmd:
-			if !config.ignoreLevelChecking {
-				viewQuestionsByLevel(&config)
-			} else {
-				viewQuestions(&config)
-			}
-		case rightAnswerCmd:
-			if !config.hasStarted {
-				printWithColorln("Interview has not yet started.", yellow, &config)
-				break
-			}
-			if config.ignoreLevelChecking {
-				if err := setAnswerAsOK(&config, db); err != nil {
-					panic(err)
-				}
-			} else {
-				if err := answerAs(&config, OK, green, db); err != nil {
-					panic(err)
-				}
-			}
+		if err != nil {
+			logError(strings.Join(flag.Args(), " "), err, &config)
+			fmt.Fprintf(os.Stderr, "interview: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 
-		case wrongAnswerCmd:
-			if !config.hasStarted {
-				printWithColorln("Interview has not yet started.", yellow, &config)
-				break
-			}
+	if len(*script) > 0 || !readline.DefaultIsTerminal() {
+		var source io.Reader = os.Stdin
+		if len(*script) > 0 {
+			source = strings.NewReader(*script)
+		}
+		console, err := newScriptConsole(source)
+		if err != nil {
+			panic(err)
+		}
+		config.input = console
+		if err := runScript(console, &config, db); err != nil {
+			fmt.Fprintf(os.Stderr, "interview: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 
-			if config.ignoreLevelChecking {
-				if err := setAnswerAsWrong(&config, db); err != nil {
-					panic(err)
-				}
-			} else {
-				if err := answerAs(&config, Wrong, red, db); err != nil {
-					panic(err)
-				}
-			}
+	input, err := newConsole(&config, db)
+	if err != nil {
+		panic(err)
+	}
+	defer input.close()
+	config.input = input
 
-		case mehAnswerCmd:
-			if !config.hasStarted {
-				printWithColorln("Interview has not yet started.", yellow, &config)
-				break
-			}
+	if err := offerSessionRestore(&config, db); err != nil {
+		printWithColorln(fmt.Sprintf("The last session couldn't be restored: %s", err), red, &config)
+	}
 
-			if config.ignoreLevelChecking {
-				if err := setAnswerAsNeutral(&config, db); err != nil {
-					panic(err)
-				}
-			} else {
-				if err := answerAs(&config, Neutral, yellow, db); err != nil {
-					panic(err)
-				}
-			}
+	for {
+		paceInterview(&config, time.Now())
+		text, err := input.readCommand(promptString(&config, time.Now()))
+		if err == readline.ErrInterrupt {
+			continue
+		}
+		if err == io.EOF {
+			printWithColorln("Bye", magenta, &config)
+			return
+		}
+		if err != nil {
+			panic(err)
+		}
+		text = strings.TrimSpace(text)
+		if len(text) == 0 {
+			continue
+		}
 
-		case finishCmd:
-			printWithColorln(fmt.Sprintf("Interview for '%s' has been saved.\n\n\tBye ...", config.interview.Interviewee), green, &config)
-			os.Exit(0)
-		case increaseLevelCmd:
-			increaseLevel(&config)
-		case decreaseLevelCmd:
-			decreaseLevel(&config)
-		case ignoreLevelCmd:
-			toggleLevelChecking(&config)
-		case showLevelCmd:
-			showLevel(&config)
-		case showStatsCmd:
-			if err := showStats(&config, db); err != nil {
-				panic(err)
-			}
-		case setAssociateProgrammerLevelCmd:
-			setLevel(AssociateOrProgrammer, &config)
-		case setProgrammerAnalystLevelCmd:
-			setLevel(ProgrammerAnalyst, &config)
-		case setSRProgrammerLevelCmd:
-			setLevel(SrProgrammer, &config)
-		case countCmd:
-			showCounts(&config)
-		case createCommentCmd:
-			fmt.Printf("Comment: ")
-			comment, err := readComment()
-			if err != nil {
-				panic(err)
-			}
-			config.comment = comment
-		case createQuestionCmd:
-			if err := makeQuestion(&config, db); err != nil {
-				panic(err)
-			}
-			printWithColorln("Question created", magenta, &config)
-		case viewCurrentQuestionAnwswerCmd:
-			viewAnswer(config.questionIndex, &config)
-		case viewAnswersCmd:
-			candidateID := config.intervieweeID
-			err := listAnswers(candidateID, &config, db)
-			if err != nil {
-				panic(err)
-			}
-		case listCandidatesCmd:
-			candidates, err := getCandidates(db)
-			if err != nil {
-				panic(err)
-			}
-			for _, candidate := range candidates {
-				fmt.Println(candidate)
-			}
-		case exploreInterviewCmd:
-			err := exploreInterview(&config, db)
-			if err != nil {
-				printWithColorln(err.Error(), red, &config)
-			}
+		spec, options, err := commands.parse(text)
+		if err == errUnknownCommand {
+			continue
+		}
+		if err != nil {
+			printWithColorln(err.Error(), red, &config)
+			continue
+		}
+		if err := runCommand(spec, options, &config, db); err != nil {
+			reportError(text, err, &config)
+		}
+		if err := saveSession(&config, sessionPath()); err != nil {
+			logError(text, err, &config)
 		}
 	}
 
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// planStep asks count questions of a topic, of the given level or of any level when it is 0.
+type planStep struct {
+	Topic string
+	Level Level
+	Count int
+}
+
+func (s planStep) String() string {
+	if s.Level == 0 {
+		return fmt.Sprintf("%d %s", s.Count, s.Topic)
+	}
+	return fmt.Sprintf("%d %s (%s)", s.Count, s.Topic, s.Level)
+}
+
+// interviewPlan is a template of the topics, levels and number of questions of an interview,
+// e.g. for a backend role: 2 java PA, 2 java Sr, 3 sql and 2 rest.
+type interviewPlan struct {
+	Name  string
+	Steps []planStep
+}
+
+func (p interviewPlan) String() string {
+	steps := make([]string, len(p.Steps))
+	for i, step := range p.Steps {
+		steps[i] = step.String()
+	}
+	return fmt.Sprintf("%s: %s", p.Name, strings.Join(steps, ", "))
+}
+
+// plansFrom reads the plans of the settings file, e.g.
+//
+//	plans:
+//	  backend-sr:
+//	    - {topic: java, level: pa, count: 2}
+//	    - {topic: java, level: sr, count: 2}
+//	    - {topic: sql, count: 3}
+func plansFrom(v *viper.Viper) (map[string]interviewPlan, error) {
+	raw := make(map[string][]struct {
+		Topic string
+		Level string
+		Count int
+	})
+	if err := v.UnmarshalKey("plans", &raw); err != nil {
+		return nil, err
+	}
+
+	plans := make(map[string]interviewPlan)
+	for name, rawSteps := range raw {
+		if len(rawSteps) == 0 {
+			return nil, fmt.Errorf("plan '%s' has no steps", name)
+		}
+		plan := interviewPlan{Name: name}
+		for i, raw := range rawSteps {
+			lvl, ok := levelFromName(raw.Level)
+			if !ok {
+				return nil, fmt.Errorf("plan '%s', step %d: unknown level '%s', use ap, pa or sr", name, i+1, raw.Level)
+			}
+			if len(raw.Topic) == 0 || raw.Count <= 0 {
+				return nil, fmt.Errorf("plan '%s', step %d: a topic and a count greater than zero are required", name, i+1)
+			}
+			plan.Steps = append(plan.Steps, planStep{Topic: strings.ToLower(raw.Topic), Level: lvl, Count: raw.Count})
+		}
+		plans[name] = plan
+	}
+	return plans, nil
+}
+
+// levelFromName maps the names of the level commands to their level, an empty name is any level.
+func levelFromName(name string) (Level, bool) {
+	switch strings.ToLower(name) {
+	case "":
+		return 0, true
+	case "ap":
+		return AssociateOrProgrammer, true
+	case "pa":
+		return ProgrammerAnalyst, true
+	case "sr":
+		return SrProgrammer, true
+	}
+	return 0, false
+}
+
+// extractPlanName removes "--plan name" from the arguments of start.
+func extractPlanName(args []string) (string, []string, error) {
+	if len(args) == 0 || args[0] != "--plan" {
+		return "", args, nil
+	}
+	if len(args) < 2 {
+		return "", args, newCommandError("--plan needs the name of a plan")
+	}
+	return strings.ToLower(args[1]), args[2:], nil
+}
+
+func findPlan(name string, config *Config) (interviewPlan, error) {
+	plan, ok := config.plans[name]
+	if !ok {
+		return interviewPlan{}, newCommandError("plan '%s' not found, the plans are: %s", name, strings.Join(planNames(config), ", "))
+	}
+	return plan, nil
+}
+
+func planNames(config *Config) []string {
+	names := make([]string, 0, len(config.plans))
+	for name := range config.plans {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// stepProgress counts the marked questions that count for the step.
+func stepProgress(step planStep, config *Config) int {
+	done := 0
+	for _, q := range config.interview.Topics[step.Topic] {
+		if step.Level != 0 && q.Level != step.Level {
+			continue
+		}
+		switch q.Result {
+		case OK, Wrong, Neutral:
+			done++
+		}
+	}
+	if done > step.Count {
+		return step.Count
+	}
+	return done
+}
+
+// activatePlanStep loads the topic of the current step and moves to its first question
+// that hasn't been marked.
+func activatePlanStep(config *Config, db *sql.DB) error {
+	step := config.plan.Steps[config.planStep]
+	if _, loaded := config.interview.Topics[step.Topic]; !loaded {
+		if err := setTopic([]string{step.Topic}, config, db); err != nil {
+			return err
+		}
+		config.individualLevelIndexes = []int{0, 0, 0}
+	}
+	config.selectedTopic = step.Topic
+	config.ignoreLevelChecking = false
+	if step.Level != 0 {
+		config.levelIndex = int(step.Level) - 1
+	}
+
+	for i, q := range config.interview.Topics[step.Topic] {
+		if (step.Level == 0 || q.Level == step.Level) && q.Result != OK && q.Result != Wrong && q.Result != Neutral {
+			moveToQuestion(i, config)
+			break
+		}
+	}
+	printWithColorln(fmt.Sprintf("Plan %s, step %d/%d: %s", config.plan.Name, config.planStep+1, len(config.plan.Steps), step), cyan, config)
+	return nil
+}
+
+// advancePlan moves the interview to the next step of the plan once the current one is done.
+func advancePlan(config *Config, db *sql.DB) error {
+	if config.plan == nil || config.planStep >= len(config.plan.Steps) {
+		return nil
+	}
+	if step := config.plan.Steps[config.planStep]; stepProgress(step, config) < step.Count {
+		return nil
+	}
+	return nextPlanStep(config, db)
+}
+
+func nextPlanStep(config *Config, db *sql.DB) error {
+	for {
+		config.planStep++
+		if config.planStep >= len(config.plan.Steps) {
+			printWithColorln(fmt.Sprintf("Plan %s is complete.", config.plan.Name), green, config)
+			return nil
+		}
+		if step := config.plan.Steps[config.planStep]; stepProgress(step, config) < step.Count {
+			break
+		}
+	}
+	if err := activatePlanStep(config, db); err != nil {
+		return err
+	}
+	printQuestion(config.questionIndex, config)
+	return nil
+}
+
+func printPlanProgress(config *Config) {
+	for i, step := range config.plan.Steps {
+		marker := "  "
+		color := gray
+		done := stepProgress(step, config)
+		switch {
+		case done >= step.Count:
+			marker = "✓ "
+			color = green
+		case i == config.planStep:
+			marker = "> "
+			color = yellow
+		}
+		printWithColorln(fmt.Sprintf("%s%d. %s  %d/%d", marker, i+1, step, done, step.Count), color, config)
+	}
+}
+
+func printPlans(config *Config) {
+	if len(config.plans) == 0 {
+		printWithColorln(fmt.Sprintf("There are no plans, add them to $HOME/%s.yaml", settingsFileName), yellow, config)
+		return
+	}
+	for _, name := range planNames(config) {
+		fmt.Println(config.plans[name])
+	}
+}
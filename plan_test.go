@@ -0,0 +1,166 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+const planSettings = `
+plans:
+  backend-sr:
+    - {topic: Java, level: pa, count: 2}
+    - {topic: java, level: sr, count: 1}
+    - {topic: sql, count: 2}
+`
+
+func readPlans(t *testing.T, settings string) (map[string]interviewPlan, error) {
+	t.Helper()
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(strings.NewReader(settings)); err != nil {
+		t.Fatal(err)
+	}
+	return plansFrom(v)
+}
+
+func Test_plansFrom(t *testing.T) {
+	plans, err := readPlans(t, planSettings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, ok := plans["backend-sr"]
+	if !ok {
+		t.Fatalf("plan backend-sr not found in %v", plans)
+	}
+	want := []planStep{
+		{Topic: "java", Level: ProgrammerAnalyst, Count: 2},
+		{Topic: "java", Level: SrProgrammer, Count: 1},
+		{Topic: "sql", Count: 2},
+	}
+	if len(plan.Steps) != len(want) {
+		t.Fatalf("got=[%v], want=[%v]", plan.Steps, want)
+	}
+	for i := range want {
+		if plan.Steps[i] != want[i] {
+			t.Errorf("step %d: got=[%v], want=[%v]", i+1, plan.Steps[i], want[i])
+		}
+	}
+
+	invalid := []string{
+		"plans:\n  x:\n    - {topic: java, level: expert, count: 2}\n",
+		"plans:\n  x:\n    - {topic: java, count: 0}\n",
+		"plans:\n  x:\n    - {level: pa, count: 1}\n",
+	}
+	for _, settings := range invalid {
+		if _, err := readPlans(t, settings); err == nil {
+			t.Errorf("settings=[%s]: want error", settings)
+		}
+	}
+}
+
+func Test_readSettings(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := readSettings(dir); err != nil {
+		t.Errorf("a missing settings file should not be an error, got=[%v]", err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, settingsFileName+".yaml"), []byte(planSettings), 0600); err != nil {
+		t.Fatal(err)
+	}
+	v, err := readSettings(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plans, err := plansFrom(v)
+	if err != nil || len(plans) != 1 {
+		t.Errorf("got plans=[%v] error=[%v]", plans, err)
+	}
+}
+
+func Test_extractPlanName(t *testing.T) {
+	name, rest, err := extractPlanName([]string{"--plan", "Backend-SR", "Leo", "Messi"})
+	if err != nil || name != "backend-sr" || !EqualTopics(rest, []string{"Leo", "Messi"}) {
+		t.Errorf("got name=[%s] rest=[%v] error=[%v]", name, rest, err)
+	}
+	name, rest, err = extractPlanName([]string{"Leo"})
+	if err != nil || name != "" || !EqualTopics(rest, []string{"Leo"}) {
+		t.Errorf("got name=[%s] rest=[%v] error=[%v]", name, rest, err)
+	}
+	if _, _, err := extractPlanName([]string{"--plan"}); err == nil {
+		t.Errorf("want error")
+	}
+}
+
+func Test_startHandler_cancelledPlan(t *testing.T) {
+	plans, err := readPlans(t, planSettings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := NewConfig()
+	config.plans = plans
+	config.input = &scriptConsole{}
+	if err := startHandler([]string{"--plan", "backend-sr"}, &config, nil); err == nil {
+		t.Fatalf("the name can't be read, want error")
+	}
+	if config.plan != nil || len(config.selectedTopic) != 0 {
+		t.Errorf("a cancelled start should leave no plan, got plan=[%v] topic=[%s]", config.plan, config.selectedTopic)
+	}
+}
+
+func Test_advancePlan(t *testing.T) {
+	plans, err := readPlans(t, planSettings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan := plans["backend-sr"]
+
+	config := NewConfig()
+	config.hasStarted = true
+	config.interview.Topics["java"] = []Question{
+		{ID: 1, Level: ProgrammerAnalyst}, {ID: 2, Level: ProgrammerAnalyst},
+		{ID: 3, Level: ProgrammerAnalyst}, {ID: 4, Level: SrProgrammer},
+	}
+	config.interview.Topics["sql"] = []Question{{ID: 5, Level: AssociateOrProgrammer}, {ID: 6, Level: SrProgrammer}}
+	config.plan = &plan
+
+	if err := activatePlanStep(&config, nil); err != nil {
+		t.Fatal(err)
+	}
+	if q, _ := currentQuestion(&config); q.ID != 1 {
+		t.Errorf("got question=[%d], want=[1]", q.ID)
+	}
+
+	mark := func(topic string, id int) {
+		qs := config.interview.Topics[topic]
+		markQuestionAs(id, OK, &qs)
+		if err := advancePlan(&config, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mark("java", 1)
+	if config.planStep != 0 {
+		t.Errorf("got step=[%d], want=[0]", config.planStep)
+	}
+	mark("java", 2)
+	if q, _ := currentQuestion(&config); config.planStep != 1 || q.ID != 4 {
+		t.Errorf("got step=[%d] question=[%d], want step=[1] question=[4]", config.planStep, q.ID)
+	}
+	mark("java", 4)
+	if q, _ := currentQuestion(&config); config.planStep != 2 || config.selectedTopic != "sql" || q.ID != 5 {
+		t.Errorf("got step=[%d] topic=[%s] question=[%d], want step=[2] topic=[sql] question=[5]",
+			config.planStep, config.selectedTopic, q.ID)
+	}
+	if got := stepProgress(plan.Steps[2], &config); got != 0 {
+		t.Errorf("got progress=[%d], want=[0]", got)
+	}
+	mark("sql", 5)
+	mark("sql", 6)
+	if config.planStep != len(plan.Steps) {
+		t.Errorf("got step=[%d], the plan should be complete", config.planStep)
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// promptPlaceholder matches the placeholders of a prompt template, e.g. {topic} or {topic|faint}.
+var promptPlaceholder = regexp.MustCompile(`\{([a-z0-9]+)(?:\|([a-z]+))?\}`)
+
+// promptStyles are the styles a placeholder can be printed with.
+var promptStyles = map[string]string{
+	"faint":   "",
+	"bold":    "",
+	"red":     red,
+	"green":   green,
+	"yellow":  yellow,
+	"blue":    blue,
+	"magenta": magenta,
+	"cyan":    cyan,
+	"gray":    gray,
+}
+
+// promptFields are the values a prompt template can show.
+var promptFields = map[string]func(config *Config, now time.Time) string{
+	"topic": func(config *Config, now time.Time) string { return config.selectedTopic },
+	"level": func(config *Config, now time.Time) string {
+		if config.ignoreLevelChecking {
+			return "any level"
+		}
+		return config.levels[config.levelIndex].String()
+	},
+	"name": func(config *Config, now time.Time) string { return config.interview.Interviewee },
+	"candidate": func(config *Config, now time.Time) string {
+		if len(config.selectedTopic) == 0 {
+			return ""
+		}
+		return shortIntervieweeName(config.interview.Interviewee, minNumberOfCharsInIntervieweeName)
+	},
+	"position": func(config *Config, now time.Time) string {
+		q, ok := currentQuestion(config)
+		if !ok || !config.hasStarted {
+			return ""
+		}
+		return fmt.Sprintf("#%d", questionPosition(q.ID, config))
+	},
+	"answered": func(config *Config, now time.Time) string {
+		tally := resultTally{}
+		for _, q := range config.interview.Topics[config.selectedTopic] {
+			tally.add(q.Result)
+		}
+		return fmt.Sprint(tally.total() - tally.notAnswered)
+	},
+	"total": func(config *Config, now time.Time) string {
+		return fmt.Sprint(len(config.interview.Topics[config.selectedTopic]))
+	},
+	"elapsed": func(config *Config, now time.Time) string {
+		if !config.hasStarted {
+			return ""
+		}
+		return formatDuration(now.Sub(config.interview.Date))
+	},
+	"left": timeLeft,
+	"db":   func(config *Config, now time.Time) string { return config.dbName },
+	"ps1":  func(config *Config, now time.Time) string { return config.ps1 },
+}
+
+// validatePromptTemplate checks that every placeholder and style of the template exists.
+func validatePromptTemplate(template string) error {
+	for _, match := range promptPlaceholder.FindAllStringSubmatch(template, -1) {
+		if _, ok := promptFields[match[1]]; !ok {
+			return fmt.Errorf("prompt: unknown placeholder '{%s}', use one of: %s", match[1], promptFieldNames())
+		}
+		if _, ok := promptStyles[match[2]]; !ok && len(match[2]) > 0 {
+			return fmt.Errorf("prompt: unknown style '%s' in '%s'", match[2], match[0])
+		}
+	}
+	return nil
+}
+
+func promptFieldNames() string {
+	names := make([]string, 0, len(promptFields))
+	for name := range promptFields {
+		names = append(names, "{"+name+"}")
+	}
+	sort.Strings(names)
+	return strings.Join(names, " ")
+}
+
+// renderPrompt fills the template with the state of the interview.
+func renderPrompt(template string, config *Config, now time.Time) string {
+	return promptPlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		match := promptPlaceholder.FindStringSubmatch(placeholder)
+		field, ok := promptFields[match[1]]
+		if !ok {
+			return placeholder
+		}
+		value := field(config, now)
+		if len(value) == 0 {
+			return value
+		}
+		s := style(config, value)
+		switch match[2] {
+		case "":
+			return value
+		case "faint":
+			return s.Faint().String()
+		case "bold":
+			return s.Bold().String()
+		}
+		return s.Foreground(color(config, promptStyles[match[2]])).String()
+	})
+}
+
+// promptString is the prompt of the REPL, the default one unless a template was configured.
+func promptString(config *Config, now time.Time) string {
+	if len(config.promptTemplate) == 0 {
+		return ps1String(config, config.ps1, config.selectedTopic, config.interview.Interviewee, timeLeft(config, now))
+	}
+	return renderPrompt(config.promptTemplate, config, now)
+}
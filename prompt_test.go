@@ -0,0 +1,71 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/muesli/termenv"
+)
+
+func Test_validatePromptTemplate(t *testing.T) {
+	valid := []string{"", "{topic} $ ", "{topic|faint} {level|cyan} {position} {answered}/{total} [{elapsed}] {ps1}", "{db|bold}> "}
+	for _, template := range valid {
+		if err := validatePromptTemplate(template); err != nil {
+			t.Errorf("template=[%s]: got error=[%v]", template, err)
+		}
+	}
+	invalid := []string{"{topik} $ ", "{topic|blink} $ "}
+	for _, template := range invalid {
+		if err := validatePromptTemplate(template); err == nil {
+			t.Errorf("template=[%s]: want error", template)
+		}
+	}
+}
+
+func Test_renderPrompt(t *testing.T) {
+	start := time.Date(2020, 6, 26, 10, 0, 0, 0, time.UTC)
+	config := undoTestConfig()
+	config.interview.Interviewee = "Leo"
+	config.interview.Date = start
+	config.dbName = "recruitment_interviews_prod"
+	qs := config.interview.Topics["java"]
+	markQuestionAs(1, OK, &qs)
+	config.individualLevelIndexes[0] = 1
+
+	got := renderPrompt("{db} /{topic} {name} {level} {position} {answered}/{total} {elapsed} {left}{ps1}", &config, start.Add(90*time.Second))
+	want := "recruitment_interviews_prod /java Leo AssociateOrProgrammer #2 1/4 01:30 $ "
+	if got != want {
+		t.Errorf("got=[%s], want=[%s]", got, want)
+	}
+
+	config.ignoreLevelChecking = true
+	config.timebox.total = 45 * time.Minute
+	if got, want := renderPrompt("{level} {left} {unknown}", &config, start), "any level 45:00 left {unknown}"; got != want {
+		t.Errorf("got=[%s], want=[%s]", got, want)
+	}
+}
+
+func Test_promptString(t *testing.T) {
+	config := NewConfig()
+	config.ps1 = "> "
+	if got := promptString(&config, time.Now()); got != "> " {
+		t.Errorf("got=[%s], want=[> ]", got)
+	}
+
+	config.selectedTopic = "sql"
+	config.interview.Interviewee = "Brenda"
+	config.colorProfile = termenv.TrueColor
+	want := ps1String(&config, config.ps1, config.selectedTopic, config.interview.Interviewee, "")
+	if got := promptString(&config, time.Now()); got != want {
+		t.Errorf("the default prompt changed, got=[%q], want=[%q]", got, want)
+	}
+	config.colorProfile = termenv.Ascii
+	if got := promptString(&config, time.Now()); got != "/sql (Brenda) > " {
+		t.Errorf("a plain prompt should have no escape codes, got=[%q]", got)
+	}
+
+	config.promptTemplate = "{topic}{ps1}"
+	if got := promptString(&config, time.Now()); got != "sql> " {
+		t.Errorf("got=[%s], want=[sql> ]", got)
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// discriminationGroup is the share of candidates, the best and the worst ones, whose
+// results are compared to tell how well a question separates them.
+const discriminationGroup = 0.27
+
+// defaultMinAsked is how many times a question has to be asked before it is flagged.
+const defaultMinAsked = 5
+
+// questionStats are the results of a question across every candidate.
+type questionStats struct {
+	id       int
+	question string
+	topic    string
+	level    Level
+	asked    int
+	ok       int
+	wrong    int
+	neutral  int
+	// discrimination goes from -1 to 1: the share of the best candidates that answered
+	// OK minus the share of the worst ones, it is only valid when both groups were asked.
+	discrimination float64
+	discriminates  bool
+	flag           string
+}
+
+func (q questionStats) percent(n int) float64 {
+	return perc(n, q.asked)
+}
+
+// buildQuestionStats computes the stats of every question asked, the candidates are
+// ranked by their score to split them into the best and the worst ones.
+func buildQuestionStats(answers map[int][]AnswerView, w scoreWeights, minAsked int) []questionStats {
+	type rankedAnswers struct {
+		percent float64
+		answers []AnswerView
+	}
+	candidates := make([]rankedAnswers, 0, len(answers))
+	for _, candidateAnswers := range answers {
+		s := scoreAnswers(candidateAnswers, w)
+		if s.answered > 0 {
+			candidates = append(candidates, rankedAnswers{percent: s.percent(), answers: candidateAnswers})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].percent > candidates[j].percent })
+
+	groupSize := int(math.Round(float64(len(candidates)) * discriminationGroup))
+	if groupSize < 1 && len(candidates) >= 2 {
+		groupSize = 1
+	}
+	type groupCount struct{ asked, ok int }
+	upper := make(map[int]*groupCount)
+	lower := make(map[int]*groupCount)
+	stats := make(map[int]*questionStats)
+	for i, candidate := range candidates {
+		for _, ans := range candidate.answers {
+			q, ok := stats[ans.QuestionID]
+			if !ok {
+				q = &questionStats{id: ans.QuestionID, question: ans.Question, topic: ans.Topic, level: ans.Level}
+				stats[ans.QuestionID] = q
+				upper[ans.QuestionID], lower[ans.QuestionID] = &groupCount{}, &groupCount{}
+			}
+			q.asked++
+			switch Result(ans.Result) {
+			case OK:
+				q.ok++
+			case Wrong:
+				q.wrong++
+			case Neutral:
+				q.neutral++
+			}
+
+			var group *groupCount
+			switch {
+			case i < groupSize:
+				group = upper[ans.QuestionID]
+			case i >= len(candidates)-groupSize:
+				group = lower[ans.QuestionID]
+			default:
+				continue
+			}
+			group.asked++
+			if Result(ans.Result) == OK {
+				group.ok++
+			}
+		}
+	}
+
+	result := make([]questionStats, 0, len(stats))
+	for id, q := range stats {
+		if u, l := upper[id], lower[id]; u.asked > 0 && l.asked > 0 {
+			q.discrimination = float64(u.ok)/float64(u.asked) - float64(l.ok)/float64(l.asked)
+			q.discriminates = true
+		}
+		q.flag = questionFlag(*q, minAsked)
+		result = append(result, *q)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.topic != b.topic {
+			return a.topic < b.topic
+		}
+		if a.level != b.level {
+			return a.level < b.level
+		}
+		return a.id < b.id
+	})
+	return result
+}
+
+// questionFlag tells what to do with a question that doesn't tell candidates apart.
+func questionFlag(q questionStats, minAsked int) string {
+	switch {
+	case q.asked < minAsked:
+		return ""
+	case q.ok == q.asked:
+		return "everyone passes: raise its level or retire it"
+	case q.wrong > 0 && q.ok == 0:
+		return "nobody passes: lower its level or retire it"
+	case q.discriminates && q.discrimination < 0:
+		return "weak candidates do better: review it"
+	}
+	return ""
+}
+
+func printQuestionStats(out io.Writer, stats []questionStats) {
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Q\tTOPIC\tLEVEL\tASKED\tOK\tWRONG\tNEUTRAL\tDISC\tQUESTION")
+	flagged := make([]questionStats, 0)
+	for _, q := range stats {
+		disc := "-"
+		if q.discriminates {
+			disc = fmt.Sprintf("%+.2f", q.discrimination)
+		}
+		text := shortQuestion(q.question)
+		mark := ""
+		if len(q.flag) > 0 {
+			mark = "!"
+			flagged = append(flagged, q)
+		}
+		fmt.Fprintf(tw, "%s%d\t%s\t%s\t%d\t%.0f%%\t%.0f%%\t%.0f%%\t%s\t%s\n", mark, q.id, q.topic, levelShortNames[q.level],
+			q.asked, q.percent(q.ok), q.percent(q.wrong), q.percent(q.neutral), disc, text)
+	}
+	tw.Flush()
+
+	if len(flagged) > 0 {
+		fmt.Fprintf(out, "\n%d questions flagged:\n", len(flagged))
+		for _, q := range flagged {
+			fmt.Fprintf(out, "\tQ%d %s\n", q.id, q.flag)
+		}
+	}
+}
+
+// parseQuestionStatsArgs reads the arguments of qstats: an optional topic and --min.
+func parseQuestionStatsArgs(args []string) (string, int, error) {
+	topic, minAsked := "", defaultMinAsked
+	for i := 0; i < len(args); i++ {
+		if args[i] != "--min" {
+			if len(topic) > 0 {
+				return "", 0, newCommandError("only one topic can be given")
+			}
+			topic = strings.ToLower(args[i])
+			continue
+		}
+		if i+1 == len(args) {
+			return "", 0, newCommandError("--min needs a number")
+		}
+		i++
+		n, err := strconv.Atoi(args[i])
+		if err != nil || n < 1 {
+			return "", 0, newCommandError("'%s' is not a number of times asked", args[i])
+		}
+		minAsked = n
+	}
+	return topic, minAsked, nil
+}
+
+// questionStatistics is qstats: how often each question was asked, how it was answered
+// and how well it separates strong from weak candidates.
+func questionStatistics(args []string, config *Config, db *sql.DB) error {
+	topic, minAsked, err := parseQuestionStatsArgs(args)
+	if err != nil {
+		return err
+	}
+	answers, err := getAnswersByCandidate(db)
+	if err != nil {
+		return err
+	}
+
+	stats := buildQuestionStats(answers, config.scoring, minAsked)
+	if len(topic) > 0 {
+		filtered := make([]questionStats, 0)
+		for _, q := range stats {
+			if q.topic == topic {
+				filtered = append(filtered, q)
+			}
+		}
+		stats = filtered
+	}
+	if len(stats) == 0 {
+		printWithColorln("No answers to compute the stats from.", yellow, config)
+		return nil
+	}
+
+	fmt.Printf("%d candidates, DISC compares the best and the worst %.0f%% of them, questions asked at least %d times are flagged.\n\n",
+		len(answers), discriminationGroup*100, minAsked)
+	printQuestionStats(os.Stdout, stats)
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_buildQuestionStats(t *testing.T) {
+	answer := func(questionID int, result Result) AnswerView {
+		return AnswerView{QuestionID: questionID, Question: "q", Topic: "java", Level: AssociateOrProgrammer, Result: int(result)}
+	}
+	answers := map[int][]AnswerView{
+		1: {answer(1, OK), answer(2, OK), answer(3, OK)},
+		2: {answer(1, OK), answer(2, OK), answer(3, Wrong)},
+		3: {answer(1, OK), answer(2, Wrong), answer(3, Wrong)},
+		4: {answer(1, OK), answer(2, Wrong), answer(3, Wrong), answer(4, Wrong)},
+	}
+	stats := buildQuestionStats(answers, defaultScoreWeights, 4)
+	if len(stats) != 4 {
+		t.Fatalf("got=[%+v]", stats)
+	}
+
+	q1, q2, q4 := stats[0], stats[1], stats[3]
+	if q1.asked != 4 || q1.ok != 4 || !q1.discriminates || q1.discrimination != 0 {
+		t.Errorf("Q1: got=[%+v]", q1)
+	}
+	if !strings.HasPrefix(q1.flag, "everyone passes") {
+		t.Errorf("Q1: got flag=[%s]", q1.flag)
+	}
+	if q2.discrimination != 1 || len(q2.flag) > 0 {
+		t.Errorf("Q2: got=[%+v]", q2)
+	}
+	if q4.asked != 1 || q4.discriminates || len(q4.flag) > 0 {
+		t.Errorf("Q4 was asked only to the worst candidate, got=[%+v]", q4)
+	}
+
+	var out bytes.Buffer
+	printQuestionStats(&out, stats)
+	for _, want := range []string{"!1", "+1.00", "1 questions flagged", "Q1 everyone passes"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("missing [%s] in:\n%s", want, out.String())
+		}
+	}
+}
+
+func Test_questionFlag(t *testing.T) {
+	type test struct {
+		q    questionStats
+		want string
+	}
+	tests := []test{
+		{q: questionStats{asked: 5, ok: 0, wrong: 5}, want: "nobody passes"},
+		{q: questionStats{asked: 5, ok: 0, neutral: 5}, want: ""},
+		{q: questionStats{asked: 5, ok: 2, discriminates: true, discrimination: -0.5}, want: "weak candidates do better"},
+		{q: questionStats{asked: 5, ok: 2, discriminates: true, discrimination: 0.5}, want: ""},
+		{q: questionStats{asked: 2, ok: 2}, want: ""},
+	}
+	for _, tt := range tests {
+		if got := questionFlag(tt.q, defaultMinAsked); !strings.HasPrefix(got, tt.want) || (tt.want == "" && got != "") {
+			t.Errorf("q=[%+v]: got=[%s], want=[%s]", tt.q, got, tt.want)
+		}
+	}
+}
+
+func Test_parseQuestionStatsArgs(t *testing.T) {
+	topic, minAsked, err := parseQuestionStatsArgs([]string{"Java", "--min", "10"})
+	if err != nil || topic != "java" || minAsked != 10 {
+		t.Errorf("got topic=[%s] min=[%d] error=[%v]", topic, minAsked, err)
+	}
+	for _, args := range [][]string{{"--min"}, {"--min", "0"}, {"java", "sql"}} {
+		if _, _, err := parseQuestionStatsArgs(args); err == nil {
+			t.Errorf("args=%v: want error", args)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// questionFields are the fields eq can change, in the order they are asked.
+var questionFields = []string{"question", "answer", "topic", "level"}
+
+// editQuestion changes the text, answer, topic or level of a question, either with
+// inline field/value pairs (eq 42 level sr answer "...") or asking for each field.
+func editQuestion(args []string, config *Config, db *sql.DB) error {
+	id, err := parseQuestionID(args[0])
+	if err != nil {
+		return err
+	}
+	q, topic, _, err := getQuestion(id, db)
+	if err != nil {
+		return err
+	}
+
+	changes := make(map[string]string)
+	switch {
+	case len(args) > 1:
+		if len(args)%2 == 0 {
+			return newCommandError("the fields go in pairs, e.g. eq %d level sr question \"...\"", id)
+		}
+		for i := 1; i < len(args); i += 2 {
+			changes[strings.ToLower(args[i])] = args[i+1]
+		}
+	case !config.input.interactive():
+		return newCommandError("pass the fields inline, e.g. eq %d level sr", id)
+	default:
+		current := map[string]string{"question": q.Q, "answer": q.Answer, "topic": topic.Topic, "level": q.Level.String()}
+		fmt.Println("Press enter to keep the current value.")
+		for _, field := range questionFields {
+			printWithColorf(config, "%s: %s\n", gray, field, current[field])
+			value, err := config.input.readLine(strings.ToUpper(field[:1]) + field[1:] + "? ")
+			if err != nil {
+				return err
+			}
+			if value = strings.TrimSpace(value); len(value) > 0 {
+				changes[field] = value
+			}
+		}
+	}
+
+	if err := applyQuestionChanges(&q, &topic, changes, db); err != nil {
+		return err
+	}
+	if err := updateQuestion(&q, topic.ID, db); err != nil {
+		return err
+	}
+
+	updateLoadedQuestion(q, topic.Topic, config)
+	printWithColorln(fmt.Sprintf("Q%d saved: %s [%s] [%s]", q.ID, q.Q, topic.Topic, q.Level), green, config)
+	return nil
+}
+
+func parseQuestionID(arg string) (int, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(arg), "Q"))
+	if err != nil {
+		return 0, newCommandError("'%s' is not a question ID", arg)
+	}
+	return id, nil
+}
+
+func applyQuestionChanges(q *Question, topic *Topic, changes map[string]string, db *sql.DB) error {
+	for field, value := range changes {
+		value = strings.TrimSpace(value)
+		switch field {
+		case "question":
+			if len(value) == 0 {
+				return newCommandError("the question can't be empty")
+			}
+			q.Q = value
+		case "answer":
+			q.Answer = value
+		case "level":
+			lvl, err := parseLevel(value)
+			if err != nil {
+				return err
+			}
+			q.Level = lvl
+		case "topic":
+			t, err := findTopic(value, db)
+			if err != nil {
+				return err
+			}
+			*topic = t
+		default:
+			return newCommandError("unknown field '%s', the fields are: %s", field, strings.Join(questionFields, ", "))
+		}
+	}
+	return nil
+}
+
+// parseLevel takes a level as typed by the interviewer: 1 to 3, or ap, pa and sr.
+func parseLevel(value string) (Level, error) {
+	if n, err := strconv.Atoi(value); err == nil && n >= int(AssociateOrProgrammer) && n <= int(SrProgrammer) {
+		return Level(n), nil
+	}
+	if lvl, ok := levelFromName(value); ok && lvl != 0 {
+		return lvl, nil
+	}
+	return 0, newCommandError("invalid level '%s', it must be 1, 2, 3, ap, pa or sr", value)
+}
+
+// updateLoadedQuestion refreshes the text of a question in the topics already loaded, a
+// change of topic or level shows up the next time the topic is loaded.
+func updateLoadedQuestion(edited Question, topic string, config *Config) {
+	for name, qs := range config.interview.Topics {
+		for i, q := range qs {
+			if q.ID != edited.ID {
+				continue
+			}
+			qs[i].Q = edited.Q
+			qs[i].Answer = edited.Answer
+			if name != topic || q.Level != edited.Level {
+				printWithColorln(fmt.Sprintf("The new topic or level of Q%d applies the next time '%s' is loaded.", q.ID, name), yellow, config)
+			}
+		}
+	}
+}
+
+// retireQuestion hides a question from the topics from now on, the answers it already
+// has are kept.
+func retireQuestion(args []string, config *Config, db *sql.DB) error {
+	id, err := parseQuestionID(args[0])
+	if err != nil {
+		return err
+	}
+	q, topic, retired, err := getQuestion(id, db)
+	if err != nil {
+		return err
+	}
+	if retired {
+		return newCommandError("Q%d is already retired", id)
+	}
+	if err := setQuestionRetired(id, true, db); err != nil {
+		return err
+	}
+	printWithColorln(fmt.Sprintf("Q%d retired from %s: %s", q.ID, topic.Topic, q.Q), yellow, config)
+	if _, loaded := config.interview.Topics[topic.Topic]; loaded {
+		printWithColorln(fmt.Sprintf("It stays in '%s' until the topic is loaded again.", topic.Topic), yellow, config)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_parseLevel(t *testing.T) {
+	type test struct {
+		value   string
+		want    Level
+		wantErr bool
+	}
+	tests := []test{
+		{value: "1", want: AssociateOrProgrammer},
+		{value: "3", want: SrProgrammer},
+		{value: "PA", want: ProgrammerAnalyst},
+		{value: "sr", want: SrProgrammer},
+		{value: "4", wantErr: true},
+		{value: "", wantErr: true},
+		{value: "expert", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseLevel(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("value=[%s]: got=[%s] error=[%v], want=[%s] error=[%t]", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func Test_parseQuestionID(t *testing.T) {
+	for _, arg := range []string{"42", "q42", "Q42"} {
+		if id, err := parseQuestionID(arg); err != nil || id != 42 {
+			t.Errorf("arg=[%s]: got=[%d] error=[%v]", arg, id, err)
+		}
+	}
+	if _, err := parseQuestionID("#3"); err == nil {
+		t.Errorf("want error")
+	}
+}
+
+func Test_applyQuestionChanges(t *testing.T) {
+	q := Question{ID: 7, Q: "What is a JVM?", Answer: "", Level: AssociateOrProgrammer}
+	topic := Topic{ID: 1, Topic: "java"}
+
+	changes := map[string]string{"question": " What is the JVM? ", "answer": "A virtual machine", "level": "pa"}
+	if err := applyQuestionChanges(&q, &topic, changes, nil); err != nil {
+		t.Fatal(err)
+	}
+	if q.Q != "What is the JVM?" || q.Answer != "A virtual machine" || q.Level != ProgrammerAnalyst {
+		t.Errorf("got=[%+v]", q)
+	}
+
+	invalid := []map[string]string{{"question": " "}, {"level": "9"}, {"difficulty": "hard"}}
+	for _, changes := range invalid {
+		if err := applyQuestionChanges(&q, &topic, changes, nil); err == nil {
+			t.Errorf("changes=%v: want error", changes)
+		}
+	}
+}
+
+func Test_updateLoadedQuestion(t *testing.T) {
+	config := undoTestConfig()
+	updateLoadedQuestion(Question{ID: 3, Q: "edited", Answer: "new answer", Level: ProgrammerAnalyst}, "java", &config)
+	q := config.interview.Topics["java"][2]
+	if q.Q != "edited" || q.Answer != "new answer" {
+		t.Errorf("got=[%+v]", q)
+	}
+}
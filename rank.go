@@ -0,0 +1,193 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+)
+
+// rankedCandidate is a candidate with the score of all their answers, tie tells why
+// they rank below the previous candidate when both have the same score.
+type rankedCandidate struct {
+	candidate CandidateView
+	score     score
+	tie       string
+}
+
+// rankPercent is the score the ranking compares, to a tenth of a percent.
+func (r rankedCandidate) rankPercent() float64 {
+	return math.Round(r.score.percent()*10) / 10
+}
+
+// rankCandidates orders the candidates by their score, the ties go to whoever answered
+// more questions and then to whoever was interviewed first. The candidates without
+// graded answers go last.
+func rankCandidates(candidates []CandidateView, answers [][]AnswerView, w scoreWeights) []rankedCandidate {
+	ranking := make([]rankedCandidate, 0, len(candidates))
+	for i, candidate := range candidates {
+		ranking = append(ranking, rankedCandidate{candidate: candidate, score: scoreAnswers(answers[i], w)})
+	}
+	sort.SliceStable(ranking, func(i, j int) bool {
+		a, b := ranking[i], ranking[j]
+		if (a.score.answered == 0) != (b.score.answered == 0) {
+			return b.score.answered == 0
+		}
+		if a.rankPercent() != b.rankPercent() {
+			return a.rankPercent() > b.rankPercent()
+		}
+		if a.score.answered != b.score.answered {
+			return a.score.answered > b.score.answered
+		}
+		if a.candidate.Date != b.candidate.Date {
+			return a.candidate.Date < b.candidate.Date
+		}
+		return a.candidate.ID < b.candidate.ID
+	})
+
+	for i := 1; i < len(ranking); i++ {
+		above, r := ranking[i-1], &ranking[i]
+		if r.score.answered == 0 || above.rankPercent() != r.rankPercent() {
+			continue
+		}
+		switch {
+		case above.score.answered != r.score.answered:
+			r.tie = fmt.Sprintf("ties with #%d at %.1f%%, #%d answered more questions (%d vs %d)",
+				above.candidate.ID, r.rankPercent(), above.candidate.ID, above.score.answered, r.score.answered)
+		case above.candidate.Date != r.candidate.Date:
+			r.tie = fmt.Sprintf("ties with #%d at %.1f%% with %d answers, #%d was interviewed first",
+				above.candidate.ID, r.rankPercent(), r.score.answered, above.candidate.ID)
+		default:
+			r.tie = fmt.Sprintf("ties with #%d in score, answers and date, ordered by candidate #", above.candidate.ID)
+		}
+	}
+	return ranking
+}
+
+func loadRanking(filter candidateFilter, w scoreWeights, db *sql.DB) ([]rankedCandidate, error) {
+	filter.page = 0
+	candidates, _, err := findCandidates(filter, db)
+	if err != nil {
+		return []rankedCandidate{}, err
+	}
+	answers := make([][]AnswerView, 0, len(candidates))
+	for _, candidate := range candidates {
+		candidateAnswers, err := getAnswersFromCandidate(candidate.ID, db)
+		if err != nil {
+			return []rankedCandidate{}, err
+		}
+		answers = append(answers, candidateAnswers)
+	}
+	return rankCandidates(candidates, answers, w), nil
+}
+
+func printRanking(out io.Writer, ranking []rankedCandidate, w scoreWeights) {
+	fmt.Fprintf(out, "Score: %s\n\n", w)
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RANK\t#\tNAME\tDATE\tPOSITION\tSCORE\tANSWERS")
+	for i, r := range ranking {
+		rank := strconv.Itoa(i + 1)
+		if r.score.answered == 0 {
+			rank = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\t%d\n", rank, r.candidate.ID, r.candidate.Name, r.candidate.Date,
+			r.candidate.Position, r.score, r.score.answered)
+	}
+	tw.Flush()
+
+	ties := make([]string, 0)
+	for i, r := range ranking {
+		if len(r.tie) > 0 {
+			ties = append(ties, fmt.Sprintf("\t%d. #%d %s", i+1, r.candidate.ID, r.tie))
+		}
+	}
+	if len(ties) > 0 {
+		fmt.Fprintln(out, "\nTies:")
+		for _, tie := range ties {
+			fmt.Fprintln(out, tie)
+		}
+	}
+}
+
+func writeRankingCSV(out io.Writer, ranking []rankedCandidate) error {
+	w := csv.NewWriter(out)
+	if err := w.Write([]string{"rank", "id", "name", "date", "position", "score", "points", "max_points", "answers", "tie"}); err != nil {
+		return err
+	}
+	for i, r := range ranking {
+		rank := strconv.Itoa(i + 1)
+		if r.score.answered == 0 {
+			rank = ""
+		}
+		err := w.Write([]string{rank, strconv.Itoa(r.candidate.ID), r.candidate.Name, r.candidate.Date, r.candidate.Position,
+			strconv.FormatFloat(r.rankPercent(), 'f', 1, 64), strconv.FormatFloat(r.score.points, 'f', -1, 64),
+			strconv.FormatFloat(r.score.max, 'f', -1, 64), strconv.Itoa(r.score.answered), r.tie})
+		if err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// extractCSVFile removes "--csv file" from the arguments of rank.
+func extractCSVFile(args []string) (string, []string, error) {
+	rest := make([]string, 0, len(args))
+	file := ""
+	for i := 0; i < len(args); i++ {
+		if args[i] != "--csv" {
+			rest = append(rest, args[i])
+			continue
+		}
+		if i+1 == len(args) {
+			return "", args, newCommandError("--csv needs the name of a file")
+		}
+		i++
+		file = args[i]
+	}
+	return file, rest, nil
+}
+
+// rankCandidatesCommand is rank: it takes the filters of li and --csv to export the
+// ranking to a file.
+func rankCandidatesCommand(args []string, config *Config, db *sql.DB) error {
+	file, args, err := extractCSVFile(args)
+	if err != nil {
+		return err
+	}
+	filter, err := parseCandidateFilter(args)
+	if err != nil {
+		return err
+	}
+	ranking, err := loadRanking(filter, config.scoring, db)
+	if err != nil {
+		return err
+	}
+	if len(ranking) == 0 {
+		printWithColorln("No candidates found.", yellow, config)
+		return nil
+	}
+
+	printRanking(os.Stdout, ranking, config.scoring)
+	if len(file) == 0 {
+		return nil
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err := writeRankingCSV(f, ranking); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	printWithColorln(fmt.Sprintf("Ranking saved to %s", file), green, config)
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_rankCandidates(t *testing.T) {
+	candidates := []CandidateView{
+		{ID: 1, Name: "Leo", Date: "2020-06-26 10:00:00"},
+		{ID: 2, Name: "Ana", Date: "2020-06-25 10:00:00"},
+		{ID: 3, Name: "Bob", Date: "2020-06-24 10:00:00"},
+		{ID: 4, Name: "Eva", Date: "2020-06-23 10:00:00"},
+		{ID: 5, Name: "Max", Date: "2020-06-22 10:00:00"},
+	}
+	ok := AnswerView{Result: int(OK), Level: AssociateOrProgrammer}
+	wrong := AnswerView{Result: int(Wrong), Level: AssociateOrProgrammer}
+	answers := [][]AnswerView{
+		{ok, wrong},
+		{ok, ok, wrong, wrong},
+		{ok},
+		{ok, wrong},
+		{},
+	}
+	ranking := rankCandidates(candidates, answers, defaultScoreWeights)
+
+	got := make([]int, 0, len(ranking))
+	for _, r := range ranking {
+		got = append(got, r.candidate.ID)
+	}
+	if want := []int{3, 2, 4, 1, 5}; !equalInts(got, want) {
+		t.Fatalf("got=%v, want=%v", got, want)
+	}
+	if len(ranking[0].tie) > 0 {
+		t.Errorf("#3 has no tie, got=[%s]", ranking[0].tie)
+	}
+	if !strings.Contains(ranking[2].tie, "#2 answered more questions (4 vs 2)") {
+		t.Errorf("got=[%s]", ranking[2].tie)
+	}
+	if !strings.Contains(ranking[3].tie, "#4 was interviewed first") {
+		t.Errorf("got=[%s]", ranking[3].tie)
+	}
+
+	var out bytes.Buffer
+	printRanking(&out, ranking, defaultScoreWeights)
+	if !strings.Contains(out.String(), "Ties:") || !strings.Contains(out.String(), "-     5") {
+		t.Errorf("got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := writeRankingCSV(&out, ranking); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 6 || lines[1] != "1,3,Bob,2020-06-24 10:00:00,,100.0,1,1,1," {
+		t.Errorf("got:\n%s", out.String())
+	}
+}
+
+func Test_extractCSVFile(t *testing.T) {
+	file, rest, err := extractCSVFile([]string{"--position", "backend", "--csv", "rank.csv"})
+	if err != nil || file != "rank.csv" || !EqualTopics(rest, []string{"--position", "backend"}) {
+		t.Errorf("got file=[%s] rest=[%v] error=[%v]", file, rest, err)
+	}
+	if _, _, err := extractCSVFile([]string{"--csv"}); err == nil {
+		t.Errorf("want error")
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
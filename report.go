@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// resultTally counts answers by their result.
+type resultTally struct {
+	ok, wrong, neutral, notAnswered int
+}
+
+func (t *resultTally) add(r Result) {
+	switch r {
+	case OK:
+		t.ok++
+	case Wrong:
+		t.wrong++
+	case Neutral:
+		t.neutral++
+	default:
+		t.notAnswered++
+	}
+}
+
+func (t resultTally) total() int {
+	return t.ok + t.wrong + t.neutral + t.notAnswered
+}
+
+func (t resultTally) String() string {
+	total := t.total()
+	return fmt.Sprintf("OK: %d (%.2f%%), Wrong: %d (%.2f%%), Neutral: %d (%.2f%%), Not Answered: %d (%.2f%%)",
+		t.ok, perc(t.ok, total), t.wrong, perc(t.wrong, total),
+		t.neutral, perc(t.neutral, total), t.notAnswered, perc(t.notAnswered, total))
+}
+
+// groupedTally keeps a tally per group, e.g. per topic, in the order the groups were found.
+type groupedTally struct {
+	names   []string
+	tallies map[string]*resultTally
+}
+
+func newGroupedTally() groupedTally {
+	return groupedTally{names: []string{}, tallies: make(map[string]*resultTally)}
+}
+
+func (g *groupedTally) add(name string, r Result) {
+	tally, ok := g.tallies[name]
+	if !ok {
+		tally = &resultTally{}
+		g.tallies[name] = tally
+		g.names = append(g.names, name)
+	}
+	tally.add(r)
+}
+
+// candidateReport summarizes the answers given by a candidate.
+type candidateReport struct {
+	candidate CandidateView
+	answers   []AnswerView
+	overall   resultTally
+	byTopic   groupedTally
+	byLevel   groupedTally
+}
+
+func buildReport(candidate CandidateView, answers []AnswerView) candidateReport {
+	report := candidateReport{
+		candidate: candidate,
+		answers:   answers,
+		byTopic:   newGroupedTally(),
+		byLevel:   newGroupedTally(),
+	}
+	for _, ans := range answers {
+		report.overall.add(Result(ans.Result))
+		report.byTopic.add(ans.Topic, Result(ans.Result))
+		report.byLevel.add(ans.Title, Result(ans.Result))
+	}
+	return report
+}
+
+func printReport(w io.Writer, report candidateReport) {
+	fmt.Fprintf(w, "Report for %s\n\n", report.candidate)
+	printTallies(w, report)
+
+	fmt.Fprintln(w, "\nAnswers:")
+	for _, ans := range report.answers {
+		fmt.Fprintf(w, "\t%s\n", ans)
+	}
+}
+
+// printTallies prints the results overall, by topic and by level.
+func printTallies(w io.Writer, report candidateReport) {
+	fmt.Fprintf(w, "Overall: %s\n", report.overall)
+
+	fmt.Fprintln(w, "\nBy topic:")
+	for _, topic := range report.byTopic.names {
+		fmt.Fprintf(w, "\t%s: %s\n", topic, report.byTopic.tallies[topic])
+	}
+
+	fmt.Fprintln(w, "\nBy level:")
+	for _, level := range report.byLevel.names {
+		fmt.Fprintf(w, "\t%s: %s\n", level, report.byLevel.tallies[level])
+	}
+}
+
+func writeReportCSV(w io.Writer, report candidateReport) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"scope", "name", "ok", "wrong", "neutral", "not_answered", "total"}); err != nil {
+		return err
+	}
+
+	row := func(scope, name string, t resultTally) []string {
+		return []string{scope, name, strconv.Itoa(t.ok), strconv.Itoa(t.wrong),
+			strconv.Itoa(t.neutral), strconv.Itoa(t.notAnswered), strconv.Itoa(t.total())}
+	}
+
+	rows := [][]string{row("overall", report.candidate.Name, report.overall)}
+	for _, topic := range report.byTopic.names {
+		rows = append(rows, row("topic", topic, *report.byTopic.tallies[topic]))
+	}
+	for _, level := range report.byLevel.names {
+		rows = append(rows, row("level", level, *report.byLevel.tallies[level]))
+	}
+	if err := out.WriteAll(rows); err != nil {
+		return err
+	}
+	return out.Error()
+}
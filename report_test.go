@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_buildReport(t *testing.T) {
+	answers := []AnswerView{
+		{ID: 1, Question: "q1", Result: int(OK), Topic: "java", Title: "Programmer"},
+		{ID: 2, Question: "q2", Result: int(Wrong), Topic: "java", Title: "Programmer Analyst"},
+		{ID: 3, Question: "q3", Result: int(Neutral), Topic: "sql", Title: "Programmer"},
+		{ID: 4, Question: "q4", Result: int(OK), Topic: "sql", Title: "Programmer"},
+	}
+
+	report := buildReport(CandidateView{ID: 1, Name: "Leo", Date: "2020-06-26"}, answers)
+
+	if report.overall != (resultTally{ok: 2, wrong: 1, neutral: 1}) {
+		t.Errorf("got=[%+v]", report.overall)
+	}
+	if !EqualTopics(report.byTopic.names, []string{"java", "sql"}) {
+		t.Errorf("got=[%v], want=[java sql]", report.byTopic.names)
+	}
+	if *report.byTopic.tallies["sql"] != (resultTally{ok: 1, neutral: 1}) {
+		t.Errorf("got=[%+v]", *report.byTopic.tallies["sql"])
+	}
+	if *report.byLevel.tallies["Programmer"] != (resultTally{ok: 2, neutral: 1}) {
+		t.Errorf("got=[%+v]", *report.byLevel.tallies["Programmer"])
+	}
+}
+
+func Test_writeReportCSV(t *testing.T) {
+	answers := []AnswerView{
+		{ID: 1, Question: "q1", Result: int(OK), Topic: "java", Title: "Programmer"},
+		{ID: 2, Question: "q2", Result: int(Wrong), Topic: "java", Title: "Programmer"},
+	}
+	report := buildReport(CandidateView{ID: 1, Name: "Leo", Date: "2020-06-26"}, answers)
+
+	var out bytes.Buffer
+	if err := writeReportCSV(&out, report); err != nil {
+		t.Fatal(err)
+	}
+	want := `scope,name,ok,wrong,neutral,not_answered,total
+overall,Leo,1,1,0,0,2
+topic,java,1,1,0,0,2
+level,Programmer,1,1,0,0,2
+`
+	if got := out.String(); got != want {
+		t.Errorf("got=[%s], want=[%s]", got, want)
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sampleQuestions shuffles the questions of every level and keeps at most draw of them,
+// a draw of 0 keeps all of them. The same seed and topic always give the same sequence.
+func sampleQuestions(questions []Question, topic string, seed int64, draw int) []Question {
+	rng := rand.New(rand.NewSource(seed ^ topicSeed(topic)))
+
+	sampled := make([]Question, 0, len(questions))
+	for _, lvl := range []Level{AssociateOrProgrammer, ProgrammerAnalyst, SrProgrammer} {
+		byLevel := make([]Question, 0)
+		for _, q := range questions {
+			if q.Level == lvl {
+				byLevel = append(byLevel, q)
+			}
+		}
+		rng.Shuffle(len(byLevel), func(i, j int) {
+			byLevel[i], byLevel[j] = byLevel[j], byLevel[i]
+		})
+		if draw > 0 && draw < len(byLevel) {
+			byLevel = byLevel[:draw]
+		}
+		sampled = append(sampled, byLevel...)
+	}
+	return sampled
+}
+
+// topicSeed mixes the topic into the seed, so two topics of the same interview
+// don't get their questions shuffled the same way.
+func topicSeed(topic string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(topic))
+	return int64(h.Sum64())
+}
+
+func newSeed() int64 {
+	return time.Now().UnixNano()
+}
+
+// setShuffle turns the shuffling of questions on or off, a number turns it on with that seed.
+func setShuffle(option string, config *Config) error {
+	switch strings.ToLower(option) {
+	case "on":
+		config.shuffle = true
+		config.seed = newSeed()
+	case "off":
+		config.shuffle = false
+		config.seed = 0
+		config.drawCount = 0
+	default:
+		seed, err := strconv.ParseInt(option, 10, 64)
+		if err != nil {
+			return newCommandError("'%s' is not on, off or a seed", option)
+		}
+		config.shuffle = true
+		config.seed = seed
+	}
+	return nil
+}
+
+// setDraw sets how many random questions per level are asked, shuffling is turned on if needed.
+func setDraw(option string, config *Config) error {
+	if strings.ToLower(option) == "all" {
+		config.drawCount = 0
+		return nil
+	}
+	n, err := strconv.Atoi(option)
+	if err != nil || n <= 0 {
+		return newCommandError("'%s' is not a number of questions greater than zero or all", option)
+	}
+	config.drawCount = n
+	if !config.shuffle {
+		config.shuffle = true
+		config.seed = newSeed()
+	}
+	return nil
+}
+
+func samplingDescription(config *Config) string {
+	if !config.shuffle {
+		return "database order"
+	}
+	draw := "all"
+	if config.drawCount > 0 {
+		draw = strconv.Itoa(config.drawCount)
+	}
+	return fmt.Sprintf("shuffled with seed %d, drawing %s per level", config.seed, draw)
+}
+
+// reproduceCommands are the commands that give the same sequence of questions again.
+func reproduceCommands(seed int64, draw int) string {
+	if draw > 0 {
+		return fmt.Sprintf("shuffle %d; draw %d", seed, draw)
+	}
+	return fmt.Sprintf("shuffle %d", seed)
+}
+
+// reloadTopic loads the questions of the selected topic again so the sampling options apply.
+func reloadTopic(config *Config, db *sql.DB) error {
+	if len(config.selectedTopic) == 0 {
+		return nil
+	}
+	questions, err := loadQuestionsFromTopic(config, db)
+	if err != nil {
+		return err
+	}
+	config.interview.Topics[config.selectedTopic] = questions
+	config.questionIndex = 0
+	config.individualLevelIndexes = []int{0, 0, 0}
+	return nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"testing"
+)
+
+func sampleTopic() []Question {
+	qs := make([]Question, 0)
+	for id := 1; id <= 30; id++ {
+		qs = append(qs, Question{ID: id, Level: Level((id-1)/10 + 1)})
+	}
+	return qs
+}
+
+func questionIDs(qs []Question) []int {
+	ids := make([]int, len(qs))
+	for i, q := range qs {
+		ids[i] = q.ID
+	}
+	return ids
+}
+
+func sameIDs(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func Test_sampleQuestions(t *testing.T) {
+	qs := sampleTopic()
+
+	first := questionIDs(sampleQuestions(qs, "java", 42, 0))
+	again := questionIDs(sampleQuestions(qs, "java", 42, 0))
+	if !sameIDs(first, again) {
+		t.Errorf("the same seed gave different sequences: %v, %v", first, again)
+	}
+	if len(first) != len(qs) {
+		t.Errorf("got=[%d] questions, want=[%d]", len(first), len(qs))
+	}
+	if sameIDs(first, questionIDs(qs)) {
+		t.Errorf("the questions were not shuffled: %v", first)
+	}
+	if other := questionIDs(sampleQuestions(qs, "java", 43, 0)); sameIDs(first, other) {
+		t.Errorf("different seeds gave the same sequence: %v", first)
+	}
+	if other := questionIDs(sampleQuestions(qs, "sql", 42, 0)); sameIDs(first, other) {
+		t.Errorf("different topics gave the same sequence: %v", first)
+	}
+
+	drawn := sampleQuestions(qs, "java", 42, 3)
+	if len(drawn) != 9 {
+		t.Fatalf("got=[%d] questions, want=[9]", len(drawn))
+	}
+	for i, q := range drawn {
+		if want := Level(i/3 + 1); q.Level != want {
+			t.Errorf("question %d: got level=[%s], want=[%s]", q.ID, q.Level, want)
+		}
+	}
+
+	if got := sampleQuestions(qs, "java", 42, 50); len(got) != len(qs) {
+		t.Errorf("got=[%d] questions, want=[%d]", len(got), len(qs))
+	}
+}
+
+func Test_setShuffle(t *testing.T) {
+	config := NewConfig()
+
+	if err := setShuffle("1234", &config); err != nil || !config.shuffle || config.seed != 1234 {
+		t.Errorf("got shuffle=[%t] seed=[%d] error=[%v]", config.shuffle, config.seed, err)
+	}
+	if err := setDraw("5", &config); err != nil || config.drawCount != 5 || config.seed != 1234 {
+		t.Errorf("got draw=[%d] seed=[%d] error=[%v]", config.drawCount, config.seed, err)
+	}
+	if err := setShuffle("off", &config); err != nil || config.shuffle || config.drawCount != 0 {
+		t.Errorf("got shuffle=[%t] draw=[%d] error=[%v]", config.shuffle, config.drawCount, err)
+	}
+	if err := setDraw("2", &config); err != nil || !config.shuffle {
+		t.Errorf("draw should turn shuffling on, got shuffle=[%t] error=[%v]", config.shuffle, err)
+	}
+	if err := setDraw("all", &config); err != nil || config.drawCount != 0 {
+		t.Errorf("got draw=[%d] error=[%v]", config.drawCount, err)
+	}
+
+	for _, option := range []string{"maybe", "1.5"} {
+		if err := setShuffle(option, &config); err == nil {
+			t.Errorf("shuffle %s: want error", option)
+		}
+	}
+	for _, option := range []string{"0", "-1", "some"} {
+		if err := setDraw(option, &config); err == nil {
+			t.Errorf("draw %s: want error", option)
+		}
+	}
+}
+
+func Test_reproduceCommands(t *testing.T) {
+	if got, want := reproduceCommands(7, 3), "shuffle 7; draw 3"; got != want {
+		t.Errorf("got=[%s], want=[%s]", got, want)
+	}
+	if got, want := reproduceCommands(7, 0), "shuffle 7"; got != want {
+		t.Errorf("got=[%s], want=[%s]", got, want)
+	}
+}
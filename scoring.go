@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// scoreWeights turn answers into points: each result is worth some points and they
+// are multiplied by the weight of the level and of the topic of the question. The
+// topics without a weight weigh 1.
+type scoreWeights struct {
+	results map[Result]float64
+	levels  map[Level]float64
+	topics  map[string]float64
+}
+
+// resultNames are the names of the results in the scoring settings.
+var resultNames = map[string]Result{"ok": OK, "meh": Neutral, "wrong": Wrong}
+
+// defaultScoreWeights give a point for an OK and half a point for a neutral answer, a
+// Sr Programmer question is worth three Associate Programmer ones.
+var defaultScoreWeights = scoreWeights{
+	results: map[Result]float64{OK: 1, Neutral: 0.5, Wrong: 0},
+	levels:  map[Level]float64{AssociateOrProgrammer: 1, ProgrammerAnalyst: 2, SrProgrammer: 3},
+	topics:  map[string]float64{},
+}
+
+// scoringFrom reads the scoring formula of the settings file, e.g.
+//
+//	scoring:
+//	  results: {ok: 1, meh: 0.5, wrong: 0}
+//	  levels: {ap: 1, pa: 2, sr: 3}
+//	  topics: {java: 2}
+//
+// what is left out keeps its default weight.
+func scoringFrom(v *viper.Viper) (scoreWeights, error) {
+	w := scoreWeights{results: make(map[Result]float64), levels: make(map[Level]float64), topics: make(map[string]float64)}
+	for result, points := range defaultScoreWeights.results {
+		w.results[result] = points
+	}
+	for level, weight := range defaultScoreWeights.levels {
+		w.levels[level] = weight
+	}
+
+	for name, value := range v.GetStringMapString("scoring.results") {
+		result, ok := resultNames[strings.ToLower(name)]
+		if !ok {
+			return w, fmt.Errorf("scoring.results: unknown result '%s', use ok, meh or wrong", name)
+		}
+		points, err := parseWeight(value)
+		if err != nil {
+			return w, fmt.Errorf("scoring.results.%s: %s", name, err)
+		}
+		w.results[result] = points
+	}
+	if w.results[OK] == 0 {
+		return w, fmt.Errorf("scoring.results.ok: an OK answer must be worth some points")
+	}
+	for name, value := range v.GetStringMapString("scoring.levels") {
+		level, ok := levelFromName(name)
+		if !ok || level == 0 {
+			return w, fmt.Errorf("scoring.levels: unknown level '%s', use ap, pa or sr", name)
+		}
+		weight, err := parseWeight(value)
+		if err != nil {
+			return w, fmt.Errorf("scoring.levels.%s: %s", name, err)
+		}
+		w.levels[level] = weight
+	}
+	for topic, value := range v.GetStringMapString("scoring.topics") {
+		weight, err := parseWeight(value)
+		if err != nil {
+			return w, fmt.Errorf("scoring.topics.%s: %s", topic, err)
+		}
+		w.topics[strings.ToLower(topic)] = weight
+	}
+	return w, nil
+}
+
+func parseWeight(value string) (float64, error) {
+	weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || weight < 0 {
+		return 0, fmt.Errorf("'%s' is not a weight, it must be a number from 0", value)
+	}
+	return weight, nil
+}
+
+func (w scoreWeights) topicWeight(topic string) float64 {
+	if weight, ok := w.topics[topic]; ok {
+		return weight
+	}
+	return 1
+}
+
+// String describes the formula, e.g. "results ok=1 meh=0.5 wrong=0, levels ap=1 pa=2 sr=3, topics java=2".
+func (w scoreWeights) String() string {
+	formula := fmt.Sprintf("results ok=%g meh=%g wrong=%g, levels ap=%g pa=%g sr=%g", w.results[OK], w.results[Neutral], w.results[Wrong],
+		w.levels[AssociateOrProgrammer], w.levels[ProgrammerAnalyst], w.levels[SrProgrammer])
+	topics := make([]string, 0, len(w.topics))
+	for topic, weight := range w.topics {
+		topics = append(topics, fmt.Sprintf("%s=%g", topic, weight))
+	}
+	if len(topics) > 0 {
+		sort.Strings(topics)
+		formula += ", topics " + strings.Join(topics, " ")
+	}
+	return formula
+}
+
+// score adds up the points of some answers, the answers that were not graded are left
+// out.
+type score struct {
+	points   float64
+	max      float64
+	answered int
+}
+
+func (s *score) add(ans AnswerView, w scoreWeights) {
+	result := Result(ans.Result)
+	if result == NotAnsweredYet {
+		return
+	}
+	weight := w.levels[ans.Level] * w.topicWeight(ans.Topic)
+	s.points += w.results[result] * weight
+	s.max += w.results[OK] * weight
+	s.answered++
+}
+
+func (s score) percent() float64 {
+	if s.max == 0 {
+		return 0
+	}
+	return s.points * 100 / s.max
+}
+
+func (s score) String() string {
+	if s.answered == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%% (%g/%g)", s.percent(), s.points, s.max)
+}
+
+func scoreAnswers(answers []AnswerView, w scoreWeights) score {
+	s := score{}
+	for _, ans := range answers {
+		s.add(ans, w)
+	}
+	return s
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func Test_scoreAnswers(t *testing.T) {
+	answers := []AnswerView{
+		{Result: int(OK), Level: AssociateOrProgrammer},
+		{Result: int(Neutral), Level: ProgrammerAnalyst},
+		{Result: int(Wrong), Level: SrProgrammer},
+		{Result: int(NotAnsweredYet), Level: SrProgrammer},
+	}
+	got := scoreAnswers(answers, defaultScoreWeights)
+	if got != (score{points: 2, max: 6, answered: 3}) {
+		t.Errorf("got=[%+v]", got)
+	}
+	if got.String() != "33% (2/6)" {
+		t.Errorf("got=[%s]", got)
+	}
+	if empty := scoreAnswers(nil, defaultScoreWeights); empty.String() != "-" || empty.percent() != 0 {
+		t.Errorf("got=[%s]", empty)
+	}
+}
+
+func Test_scoringFrom(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	settings := `
+scoring:
+  results: {ok: 2, meh: 1}
+  levels: {sr: 5}
+  topics: {Java: 2}
+`
+	if err := v.ReadConfig(strings.NewReader(settings)); err != nil {
+		t.Fatal(err)
+	}
+	w, err := scoringFrom(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.results[OK] != 2 || w.results[Neutral] != 1 || w.results[Wrong] != 0 {
+		t.Errorf("got results=[%v]", w.results)
+	}
+	if w.levels[SrProgrammer] != 5 || w.levels[ProgrammerAnalyst] != 2 || w.topicWeight("java") != 2 || w.topicWeight("sql") != 1 {
+		t.Errorf("got levels=[%v] topics=[%v]", w.levels, w.topics)
+	}
+	if defaultScoreWeights.levels[SrProgrammer] != 3 {
+		t.Errorf("the defaults should not change")
+	}
+	if got := w.String(); got != "results ok=2 meh=1 wrong=0, levels ap=1 pa=2 sr=5, topics java=2" {
+		t.Errorf("got=[%s]", got)
+	}
+
+	invalid := []string{
+		"scoring:\n  results: {good: 1}\n",
+		"scoring:\n  results: {ok: 0}\n",
+		"scoring:\n  levels: {jr: 1}\n",
+		"scoring:\n  topics: {java: -1}\n",
+	}
+	for _, settings := range invalid {
+		v := viper.New()
+		v.SetConfigType("yaml")
+		if err := v.ReadConfig(strings.NewReader(settings)); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := scoringFrom(v); err == nil {
+			t.Errorf("settings=[%s]: want error", settings)
+		}
+	}
+}
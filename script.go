@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bufio"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// scriptConsole feeds the commands of a script to the REPL. A script can't answer
+// the questions asked by a command, so every value must be given inline, e.g.
+// "start Leo" instead of "start" followed by the name.
+type scriptConsole struct {
+	commands []string
+	next     int
+}
+
+func newScriptConsole(script io.Reader) (*scriptConsole, error) {
+	console := &scriptConsole{}
+	scanner := bufio.NewScanner(script)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		console.commands = append(console.commands, splitCommands(line)...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return console, nil
+}
+
+func (c *scriptConsole) readCommand(prompt string) (string, error) {
+	if c.next >= len(c.commands) {
+		return "", io.EOF
+	}
+	c.next++
+	return c.commands[c.next-1], nil
+}
+
+func (c *scriptConsole) readLine(prompt string) (string, error) {
+	return "", newCommandError("'%s' needs a value, pass it inline with the command", strings.TrimSpace(prompt))
+}
+
+func (c *scriptConsole) interactive() bool {
+	return false
+}
+
+func (c *scriptConsole) close() error {
+	return nil
+}
+
+// splitCommands splits a line on the semicolons that are not quoted, quotes are
+// handled the same way words() does.
+func splitCommands(line string) []string {
+	cmds := make([]string, 0)
+	var b strings.Builder
+	var quote rune
+	wordStart := true
+	for _, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case (r == '"' || r == '\'') && wordStart:
+			quote = r
+		case r == ';':
+			if cmd := strings.TrimSpace(b.String()); len(cmd) > 0 {
+				cmds = append(cmds, cmd)
+			}
+			b.Reset()
+			wordStart = true
+			continue
+		}
+		b.WriteRune(r)
+		wordStart = unicode.IsSpace(r)
+	}
+	if cmd := strings.TrimSpace(b.String()); len(cmd) > 0 {
+		cmds = append(cmds, cmd)
+	}
+	return cmds
+}
+
+// runScript runs every command of the script, it stops at the first command that
+// fails and reports which one it was. Failures that aren't caused by the script are
+// logged like in the REPL.
+func runScript(script *scriptConsole, config *Config, db *sql.DB) error {
+	for {
+		text, err := script.readCommand("")
+		if err == io.EOF {
+			return nil
+		}
+
+		spec, options, err := commands.parse(text)
+		if err == errUnknownCommand {
+			return fmt.Errorf("command #%d '%s': unknown command", script.next, text)
+		}
+		if err != nil {
+			return fmt.Errorf("command #%d '%s': %s", script.next, text, err)
+		}
+		if err := runCommand(spec, options, config, db); err != nil {
+			var cmdErr *commandError
+			if !errors.As(err, &cmdErr) {
+				logError(text, err, config)
+			}
+			return fmt.Errorf("command #%d '%s': %s", script.next, text, err)
+		}
+	}
+}
+
+// argOrAsk returns the inline argument at index or, when it wasn't given, asks for it.
+func argOrAsk(args []string, index int, prompt string, config *Config) (string, error) {
+	if index < len(args) {
+		return args[index], nil
+	}
+	return config.input.readLine(prompt)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"strings"
+	"testing"
+)
+
+func Test_splitCommands(t *testing.T) {
+	type test struct {
+		line string
+		want []string
+	}
+
+	tests := []test{
+		{line: "use java; start Leo; y; next; n", want: []string{"use java", "start Leo", "y", "next", "n"}},
+		{line: `cmt "knows a; b"; next`, want: []string{`cmt "knows a; b"`, "next"}},
+		{line: ";; p ;", want: []string{"p"}},
+		{line: "cmt didn't know; next", want: []string{"cmt didn't know", "next"}},
+		{line: "", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		if got := splitCommands(tt.line); !EqualTopics(got, tt.want) {
+			t.Errorf("got=[%v], want=[%v]", got, tt.want)
+		}
+	}
+}
+
+func Test_newScriptConsole(t *testing.T) {
+	script := `
+# a comment
+use java; start Leo
+
+y
+`
+	console, err := newScriptConsole(strings.NewReader(script))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"use java", "start Leo", "y"}
+	for _, w := range want {
+		got, err := console.readCommand("$ ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != w {
+			t.Errorf("got=[%s], want=[%s]", got, w)
+		}
+	}
+	if _, err := console.readCommand("$ "); err != io.EOF {
+		t.Errorf("got=[%v], want=[%v]", err, io.EOF)
+	}
+	if _, err := console.readLine("Interviewee name: "); err == nil {
+		t.Error("a script can't be asked for values")
+	}
+}
+
+func Test_argOrAsk(t *testing.T) {
+	config := Config{input: &scriptConsole{}}
+
+	got, err := argOrAsk([]string{"1", "2"}, 1, "Level? ", &config)
+	if err != nil || got != "2" {
+		t.Errorf("got=[%s, %v], want=[2, <nil>]", got, err)
+	}
+
+	if _, err := argOrAsk([]string{"1"}, 1, "Level? ", &config); err == nil {
+		t.Error("expecting an error when the value is missing")
+	}
+}
+
+func Test_runScript_logsErrors(t *testing.T) {
+	var logged bytes.Buffer
+	config := NewConfig()
+	config.errorLog = log.New(&logged, "", 0)
+
+	// Marking before start is the script's fault, it is not logged.
+	script, _ := newScriptConsole(strings.NewReader("y"))
+	if err := runScript(script, &config, nil); err == nil {
+		t.Fatal("want error")
+	}
+	if logged.Len() != 0 {
+		t.Errorf("a command error shouldn't be logged, got=[%s]", logged.String())
+	}
+
+	// Without a database topics fails, the failure is logged.
+	script, _ = newScriptConsole(strings.NewReader("topics"))
+	if err := runScript(script, &config, nil); err == nil {
+		t.Fatal("want error")
+	}
+	if !strings.Contains(logged.String(), `command="topics"`) {
+		t.Errorf("the failure should be logged, got=[%s]", logged.String())
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// sessionState is what is needed to get back to a running interview after the
+// application exits: the answers themselves are already in the database.
+type sessionState struct {
+	IntervieweeID int                      `json:"interviewee_id"`
+	Interviewee   string                   `json:"interviewee"`
+	Date          time.Time                `json:"date"`
+	Topics        []string                 `json:"topics"`
+	SelectedTopic string                   `json:"selected_topic"`
+	QuestionIndex int                      `json:"question_index"`
+	LevelIndex    int                      `json:"level_index"`
+	LevelIndexes  []int                    `json:"level_indexes"`
+	IgnoreLevels  bool                     `json:"ignore_levels"`
+	Shuffle       bool                     `json:"shuffle"`
+	Seed          int64                    `json:"seed"`
+	Draw          int                      `json:"draw"`
+	Plan          string                   `json:"plan,omitempty"`
+	PlanStep      int                      `json:"plan_step"`
+	Adaptive      bool                     `json:"adaptive"`
+	UpStreak      int                      `json:"adaptive_up_streak"`
+	DownStreak    int                      `json:"adaptive_down_streak"`
+	OKStreak      int                      `json:"ok_streak"`
+	WrongStreak   int                      `json:"wrong_streak"`
+	TimeboxTotal  time.Duration            `json:"timebox_total"`
+	TimeBudgets   map[string]time.Duration `json:"time_budgets"`
+	TimeSpent     map[string]time.Duration `json:"time_spent"`
+	TimeOrder     []string                 `json:"time_order"`
+	SavedAt       time.Time                `json:"saved_at"`
+}
+
+func newSessionState(config *Config, now time.Time) sessionState {
+	topics := make([]string, 0, len(config.interview.Topics))
+	for topic := range config.interview.Topics {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+
+	state := sessionState{
+		IntervieweeID: config.intervieweeID,
+		Interviewee:   config.interview.Interviewee,
+		Date:          config.interview.Date,
+		Topics:        topics,
+		SelectedTopic: config.selectedTopic,
+		QuestionIndex: config.questionIndex,
+		LevelIndex:    config.levelIndex,
+		LevelIndexes:  append([]int{}, config.individualLevelIndexes...),
+		IgnoreLevels:  config.ignoreLevelChecking,
+		Shuffle:       config.shuffle,
+		Seed:          config.seed,
+		Draw:          config.drawCount,
+		PlanStep:      config.planStep,
+		Adaptive:      config.adaptive.enabled,
+		UpStreak:      config.adaptive.upStreak,
+		DownStreak:    config.adaptive.downStreak,
+		OKStreak:      config.okStreak,
+		WrongStreak:   config.wrongStreak,
+		TimeboxTotal:  config.timebox.total,
+		TimeBudgets:   config.timebox.budgets,
+		TimeSpent:     make(map[string]time.Duration),
+		TimeOrder:     config.timebox.order,
+		SavedAt:       now,
+	}
+	if config.plan != nil {
+		state.Plan = config.plan.Name
+	}
+	// The time of the selected topic runs until now, the time the application is closed
+	// is not charged to any topic.
+	for topic := range config.timebox.spent {
+		state.TimeSpent[topic] = config.timebox.spentOn(topic, now)
+	}
+	return state
+}
+
+func sessionPath() string {
+	return filepath.Join(os.Getenv("HOME"), sessionFileName)
+}
+
+// saveSession writes the state of a started interview, the file is replaced at once so
+// a crash while writing doesn't leave it half written.
+func saveSession(config *Config, path string) error {
+	if !config.hasStarted {
+		return nil
+	}
+	content, err := json.MarshalIndent(newSessionState(config, time.Now()), "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, content, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// loadSession reads the saved session, there is none when the file doesn't exist.
+func loadSession(path string) (*sessionState, error) {
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state sessionState
+	if err := json.Unmarshal(content, &state); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+	return &state, nil
+}
+
+func removeSession(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// restore loads the topics of the session again, with the same sampling, and puts the
+// saved answers and the cursors back.
+func (s sessionState) restore(config *Config, db *sql.DB) error {
+	config.shuffle, config.seed, config.drawCount = s.Shuffle, s.Seed, s.Draw
+
+	answers, err := getCandidateAnswers(s.IntervieweeID, db)
+	if err != nil {
+		return err
+	}
+	for _, topic := range s.Topics {
+		config.selectedTopic = topic
+		questions, err := loadQuestionsFromTopic(config, db)
+		if err != nil {
+			return err
+		}
+		applyAnswers(answers, questions)
+		config.interview.Topics[topic] = questions
+	}
+	s.restoreCursor(config)
+	return nil
+}
+
+// restoreCursor puts back where the interview was, ignoring the cursors that don't fit
+// the questions loaded, e.g. because questions were deleted in between.
+func (s sessionState) restoreCursor(config *Config) {
+	config.intervieweeID = s.IntervieweeID
+	config.interview.Interviewee = s.Interviewee
+	config.interview.Date = s.Date
+	config.hasStarted = true
+	config.selectedTopic = s.SelectedTopic
+	config.ignoreLevelChecking = s.IgnoreLevels
+	if s.LevelIndex >= 0 && s.LevelIndex < len(config.levels) {
+		config.levelIndex = s.LevelIndex
+	}
+	questions := config.interview.Topics[s.SelectedTopic]
+	if s.QuestionIndex >= 0 && s.QuestionIndex < len(questions) {
+		config.questionIndex = s.QuestionIndex
+	}
+	config.individualLevelIndexes = []int{0, 0, 0}
+	for i := 0; i < len(s.LevelIndexes) && i < len(config.individualLevelIndexes); i++ {
+		if s.LevelIndexes[i] < len(getQuestionsFromLevel(Level(i+1), config)) {
+			config.individualLevelIndexes[i] = s.LevelIndexes[i]
+		}
+	}
+	if plan, ok := config.plans[s.Plan]; ok && s.PlanStep < len(plan.Steps) {
+		config.plan, config.planStep = &plan, s.PlanStep
+	}
+	if s.UpStreak > 0 && s.DownStreak > 0 {
+		config.adaptive = adaptiveRules{enabled: s.Adaptive, upStreak: s.UpStreak, downStreak: s.DownStreak}
+	}
+	config.okStreak, config.wrongStreak = s.OKStreak, s.WrongStreak
+	config.timebox.total = s.TimeboxTotal
+	for topic, budget := range s.TimeBudgets {
+		config.timebox.budgets[topic] = budget
+	}
+	for _, topic := range s.TimeOrder {
+		config.timebox.spent[topic] = s.TimeSpent[topic]
+		config.timebox.order = append(config.timebox.order, topic)
+	}
+}
+
+func applyAnswers(answers map[int]Question, questions []Question) {
+	for i, q := range questions {
+		if answer, ok := answers[q.ID]; ok {
+			questions[i].Result = answer.Result
+			questions[i].Comment = answer.Comment
+		}
+	}
+}
+
+// offerSessionRestore asks whether to get back to the interview that was running when
+// the application exited.
+func offerSessionRestore(config *Config, db *sql.DB) error {
+	path := sessionPath()
+	state, err := loadSession(path)
+	if err != nil || state == nil {
+		return err
+	}
+
+	prompt := fmt.Sprintf("Restore the interview with '%s' (#%d, /%s, saved %s)? [Y/n] ",
+		state.Interviewee, state.IntervieweeID, state.SelectedTopic, state.SavedAt.Format(interviewFormatLayout))
+	answer, err := config.input.readLine(prompt)
+	if err != nil {
+		return err
+	}
+	if a := strings.ToLower(strings.TrimSpace(answer)); a == "n" || a == "no" {
+		return removeSession(path)
+	}
+
+	if err := state.restore(config, db); err != nil {
+		resetStatus(config)
+		return err
+	}
+	printWithColorln(fmt.Sprintf("Interview with '%s' restored.", state.Interviewee), green, config)
+	printQuestion(config.questionIndex, config)
+	return nil
+}
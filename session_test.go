@@ -0,0 +1,130 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_saveSession(t *testing.T) {
+	path := filepath.Join(t.TempDir(), sessionFileName)
+
+	config := NewConfig()
+	if err := saveSession(&config, path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("the session of an interview that hasn't started shouldn't be saved")
+	}
+
+	config.hasStarted = true
+	config.intervieweeID = 7
+	config.interview.Interviewee = "Leo"
+	config.interview.Date = time.Date(2020, 6, 26, 10, 0, 0, 0, time.UTC)
+	config.interview.Topics["sql"] = []Question{}
+	config.interview.Topics["java"] = []Question{}
+	config.selectedTopic = "java"
+	config.levelIndex = 2
+	config.individualLevelIndexes = []int{1, 0, 3}
+	config.shuffle, config.seed, config.drawCount = true, 99, 2
+	if err := saveSession(&config, path); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := loadSession(path)
+	if err != nil || state == nil {
+		t.Fatalf("got state=[%v] error=[%v]", state, err)
+	}
+	if state.IntervieweeID != 7 || state.Interviewee != "Leo" || !state.Date.Equal(config.interview.Date) ||
+		state.SelectedTopic != "java" || state.LevelIndex != 2 || state.Seed != 99 || state.Draw != 2 {
+		t.Errorf("got state=[%+v]", state)
+	}
+	if !EqualTopics(state.Topics, []string{"java", "sql"}) {
+		t.Errorf("got topics=[%v]", state.Topics)
+	}
+
+	if err := removeSession(path); err != nil {
+		t.Fatal(err)
+	}
+	if state, err := loadSession(path); state != nil || err != nil {
+		t.Errorf("got state=[%v] error=[%v], want no session", state, err)
+	}
+	if err := removeSession(path); err != nil {
+		t.Errorf("removing a missing session should not fail, got=[%v]", err)
+	}
+}
+
+func Test_loadSession_corrupted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), sessionFileName)
+	if err := ioutil.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadSession(path); err == nil {
+		t.Errorf("want error")
+	}
+}
+
+func Test_sessionState_restoreCursor(t *testing.T) {
+	config := NewConfig()
+	config.interview.Topics["java"] = []Question{
+		{ID: 1, Level: AssociateOrProgrammer}, {ID: 2, Level: AssociateOrProgrammer}, {ID: 3, Level: SrProgrammer},
+	}
+	config.plans = map[string]interviewPlan{"backend": {Name: "backend", Steps: []planStep{{Topic: "java", Count: 1}}}}
+
+	state := sessionState{
+		IntervieweeID: 3, Interviewee: "Brenda", SelectedTopic: "java",
+		LevelIndex: 2, QuestionIndex: 2, LevelIndexes: []int{1, 4, 0}, Plan: "backend",
+	}
+	state.restoreCursor(&config)
+
+	if !config.hasStarted || config.intervieweeID != 3 || config.interview.Interviewee != "Brenda" {
+		t.Errorf("got started=[%t] id=[%d] name=[%s]", config.hasStarted, config.intervieweeID, config.interview.Interviewee)
+	}
+	if config.levelIndex != 2 || config.questionIndex != 2 {
+		t.Errorf("got level=[%d] question=[%d]", config.levelIndex, config.questionIndex)
+	}
+	if config.individualLevelIndexes[0] != 1 || config.individualLevelIndexes[1] != 0 {
+		t.Errorf("got cursors=[%v], the cursor out of range should be reset", config.individualLevelIndexes)
+	}
+	if config.plan == nil || config.plan.Name != "backend" {
+		t.Errorf("got plan=[%v], want=[backend]", config.plan)
+	}
+}
+
+func Test_sessionState_adaptiveAndTimebox(t *testing.T) {
+	start := time.Date(2020, 6, 26, 10, 0, 0, 0, time.UTC)
+	config := NewConfig()
+	config.hasStarted = true
+	config.adaptive = adaptiveRules{enabled: true, upStreak: 4, downStreak: 1}
+	config.okStreak = 2
+	config.timebox.total = 45 * time.Minute
+	config.timebox.budgets["java"] = 15 * time.Minute
+	config.timebox.track("sql", start)
+	config.timebox.track("java", start.Add(5*time.Minute))
+
+	state := newSessionState(&config, start.Add(12*time.Minute))
+	if state.TimeSpent["sql"] != 5*time.Minute || state.TimeSpent["java"] != 7*time.Minute {
+		t.Errorf("got spent=[%v]", state.TimeSpent)
+	}
+
+	restored := NewConfig()
+	state.restoreCursor(&restored)
+	if restored.adaptive != config.adaptive || restored.okStreak != 2 {
+		t.Errorf("got adaptive=[%+v] streak=[%d]", restored.adaptive, restored.okStreak)
+	}
+	tb := restored.timebox
+	if tb.total != 45*time.Minute || tb.budgets["java"] != 15*time.Minute || tb.spent["java"] != 7*time.Minute ||
+		!EqualTopics(tb.order, []string{"sql", "java"}) || len(tb.current) != 0 {
+		t.Errorf("got timebox=[%+v]", tb)
+	}
+}
+
+func Test_applyAnswers(t *testing.T) {
+	questions := []Question{{ID: 1}, {ID: 2}}
+	applyAnswers(map[int]Question{2: {ID: 2, Result: Wrong, Comment: "no idea"}}, questions)
+	if questions[0].Result != 0 || questions[1].Result != Wrong || questions[1].Comment != "no idea" {
+		t.Errorf("got=[%v]", questions)
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/spf13/viper"
+)
+
+// readSettings reads the optional interview.yaml from configPath, it holds what doesn't
+// fit in interviews.env such as the adaptive rules. A missing file means no settings.
+func readSettings(configPath string) (*viper.Viper, error) {
+	v := viper.New()
+	v.SetConfigName(settingsFileName)
+	v.AddConfigPath(configPath)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if errors.As(err, &notFound) {
+			return v, nil
+		}
+		return v, err
+	}
+	return v, nil
+}
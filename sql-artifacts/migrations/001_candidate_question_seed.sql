@@ -0,0 +1,5 @@
+-- Records how the questions of an interview were sampled, so the same sequence
+-- can be reproduced when reviewing it.
+ALTER TABLE candidate
+  ADD COLUMN `question_seed` BIGINT NULL AFTER `date`,
+  ADD COLUMN `question_draw` INT NULL AFTER `question_seed`;
@@ -0,0 +1,15 @@
+-- History of what happened during an interview, e.g. the automatic level changes.
+CREATE TABLE IF NOT EXISTS `interview_event` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `candidate_id` INT NOT NULL,
+  `event` VARCHAR(45) NOT NULL,
+  `detail` VARCHAR(1000) NULL,
+  `created_at` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `fk_interview_event_candidate1_idx` (`candidate_id` ASC),
+  CONSTRAINT `fk_interview_event_candidate1`
+    FOREIGN KEY (`candidate_id`)
+    REFERENCES `candidate` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION)
+ENGINE = InnoDB;
@@ -0,0 +1,3 @@
+-- Retired questions are no longer loaded in interviews, their answers are kept.
+ALTER TABLE question
+  ADD COLUMN `retired` TINYINT(1) NOT NULL DEFAULT 0 AFTER `level_id`;
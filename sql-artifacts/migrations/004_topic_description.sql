@@ -0,0 +1,3 @@
+-- Topics can be described, the description is listed by the topics command.
+ALTER TABLE topic
+  ADD COLUMN `description` VARCHAR(500) NULL AFTER `topic`;
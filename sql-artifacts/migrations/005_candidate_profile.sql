@@ -0,0 +1,9 @@
+-- Optional profile of a candidate, the time of the interview and soft-deleted candidates.
+ALTER TABLE candidate
+  MODIFY COLUMN `date` DATETIME NOT NULL,
+  ADD COLUMN `email` VARCHAR(255) NULL AFTER `date`,
+  ADD COLUMN `position` VARCHAR(100) NULL AFTER `email`,
+  ADD COLUMN `seniority` VARCHAR(45) NULL AFTER `position`,
+  ADD COLUMN `source` VARCHAR(100) NULL AFTER `seniority`,
+  ADD COLUMN `notes` VARCHAR(1000) NULL AFTER `source`,
+  ADD COLUMN `deleted` TINYINT(1) NOT NULL DEFAULT 0 AFTER `notes`;
@@ -28,6 +28,7 @@ SHOW WARNINGS;
 CREATE TABLE IF NOT EXISTS `recruitment_interviews_prod`.`topic` (
   `id` INT NOT NULL AUTO_INCREMENT,
   `topic` VARCHAR(255) NOT NULL,
+  `description` VARCHAR(500) NULL,
   PRIMARY KEY (`id`))
 ENGINE = InnoDB;
 
@@ -59,6 +60,7 @@ CREATE TABLE IF NOT EXISTS `recruitment_interviews_prod`.`question` (
   `answer` VARCHAR(1000) NULL,
   `topic_id` INT NOT NULL,
   `level_id` INT NOT NULL,
+  `retired` TINYINT(1) NOT NULL DEFAULT 0,
   PRIMARY KEY (`id`),
   INDEX `fk_question_topic_idx` (`topic_id` ASC),
   INDEX `fk_question_level1_idx` (`level_id` ASC),
@@ -85,7 +87,15 @@ SHOW WARNINGS;
 CREATE TABLE IF NOT EXISTS `recruitment_interviews_prod`.`candidate` (
   `id` INT NOT NULL AUTO_INCREMENT,
   `name` VARCHAR(100) NOT NULL,
-  `date` DATE NOT NULL,
+  `date` DATETIME NOT NULL,
+  `email` VARCHAR(255) NULL,
+  `position` VARCHAR(100) NULL,
+  `seniority` VARCHAR(45) NULL,
+  `source` VARCHAR(100) NULL,
+  `notes` VARCHAR(1000) NULL,
+  `deleted` TINYINT(1) NOT NULL DEFAULT 0,
+  `question_seed` BIGINT NULL,
+  `question_draw` INT NULL,
   PRIMARY KEY (`id`))
 ENGINE = InnoDB;
 
@@ -120,6 +130,29 @@ ENGINE = InnoDB;
 
 SHOW WARNINGS;
 
+-- -----------------------------------------------------
+-- Table `recruitment_interviews_prod`.`interview_event`
+-- -----------------------------------------------------
+DROP TABLE IF EXISTS `recruitment_interviews_prod`.`interview_event` ;
+
+SHOW WARNINGS;
+CREATE TABLE IF NOT EXISTS `recruitment_interviews_prod`.`interview_event` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `candidate_id` INT NOT NULL,
+  `event` VARCHAR(45) NOT NULL,
+  `detail` VARCHAR(1000) NULL,
+  `created_at` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `fk_interview_event_candidate1_idx` (`candidate_id` ASC),
+  CONSTRAINT `fk_interview_event_candidate1`
+    FOREIGN KEY (`candidate_id`)
+    REFERENCES `recruitment_interviews_prod`.`candidate` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION)
+ENGINE = InnoDB;
+
+SHOW WARNINGS;
+
 SET SQL_MODE=@OLD_SQL_MODE;
 SET FOREIGN_KEY_CHECKS=@OLD_FOREIGN_KEY_CHECKS;
 SET UNIQUE_CHECKS=@OLD_UNIQUE_CHECKS;
@@ -7,3 +7,4 @@ ALTER TABLE question AUTO_INCREMENT = 0;
 ALTER TABLE candidate AUTO_INCREMENT = 0;
 ALTER TABLE answer AUTO_INCREMENT = 0;
 ALTER TABLE level AUTO_INCREMENT = 0;
+ALTER TABLE interview_event AUTO_INCREMENT = 0;
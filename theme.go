@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+// theme maps the color keys used across the application (red, green, ...) to the
+// colors actually printed.
+type theme struct {
+	name   string
+	colors map[string]string
+}
+
+var themes = map[string]theme{
+	"dark": {name: "dark", colors: map[string]string{
+		red:     "#E88388",
+		green:   "#A8CC8C",
+		yellow:  "#DBAB79",
+		blue:    "#71BEF2",
+		magenta: "#D290E4",
+		cyan:    "#66C2CD",
+		gray:    "#B9BFCA",
+	}},
+	"light": {name: "light", colors: map[string]string{
+		red:     "#B3261E",
+		green:   "#2E7D32",
+		yellow:  "#9A6700",
+		blue:    "#0550AE",
+		magenta: "#8250DF",
+		cyan:    "#0E7490",
+		gray:    "#57606A",
+	}},
+	"high-contrast": {name: "high-contrast", colors: map[string]string{
+		red:     "9",
+		green:   "10",
+		yellow:  "11",
+		blue:    "12",
+		magenta: "13",
+		cyan:    "14",
+		gray:    "15",
+	}},
+}
+
+const defaultTheme = "dark"
+
+func findTheme(name string) (theme, error) {
+	t, ok := themes[strings.ToLower(name)]
+	if !ok {
+		return theme{}, fmt.Errorf("unknown theme '%s', the themes are: %s", name, strings.Join(themeNames(), ", "))
+	}
+	return t, nil
+}
+
+func themeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// outputProfile is the color profile of the output: plain text when NO_COLOR is set or
+// when the output is not a terminal.
+func outputProfile() termenv.Profile {
+	return termenv.EnvColorProfile()
+}
+
+// style returns text ready to be styled with the profile of the output, so it is left
+// plain when colors are off.
+func style(config *Config, text string) termenv.Style {
+	return config.colorProfile.String(text)
+}
+
+// color returns the color of the theme for a color key.
+func color(config *Config, key string) termenv.Color {
+	return config.colorProfile.Color(config.theme.colors[key])
+}
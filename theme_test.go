@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func Test_themes_defineEveryColor(t *testing.T) {
+	for name, th := range themes {
+		for _, key := range []string{red, green, yellow, blue, magenta, cyan, gray} {
+			if len(th.colors[key]) == 0 {
+				t.Errorf("theme %s has no %s color", name, key)
+			}
+		}
+	}
+}
+
+func Test_findTheme(t *testing.T) {
+	if th, err := findTheme("Light"); err != nil || th.name != "light" {
+		t.Errorf("got theme=[%s] error=[%v]", th.name, err)
+	}
+	if _, err := findTheme("solarized"); err == nil {
+		t.Errorf("want error")
+	}
+}
+
+func Test_style_plain(t *testing.T) {
+	config := NewConfig()
+	config.colorProfile = termenv.Ascii
+	if got := style(&config, "java").Bold().Foreground(color(&config, red)).String(); got != "java" {
+		t.Errorf("got=[%q], want plain text", got)
+	}
+
+	config.colorProfile = termenv.TrueColor
+	if got := style(&config, "java").Foreground(color(&config, red)).String(); got == "java" {
+		t.Errorf("got=[%q], want colored text", got)
+	}
+}
+
+func Test_outputProfile_noColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	if got := outputProfile(); got != termenv.Ascii {
+		t.Errorf("got profile=[%v], NO_COLOR should turn colors off", got)
+	}
+}
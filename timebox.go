@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// timebox keeps the time budgets of the interview and the time spent on every topic.
+type timebox struct {
+	total   time.Duration
+	budgets map[string]time.Duration
+	spent   map[string]time.Duration
+	order   []string
+	current string
+	since   time.Time
+	warned  map[string]bool
+}
+
+func newTimebox() timebox {
+	return timebox{
+		budgets: make(map[string]time.Duration),
+		spent:   make(map[string]time.Duration),
+		warned:  make(map[string]bool),
+	}
+}
+
+// timeboxFrom reads the budgets of the settings file, e.g.
+//
+//	timebox:
+//	  total: 45m
+//	  topics:
+//	    java: 15m
+//	    sql: 10m
+func timeboxFrom(v *viper.Viper) (timebox, error) {
+	tb := newTimebox()
+	if total := v.GetString("timebox.total"); len(total) > 0 {
+		d, err := time.ParseDuration(total)
+		if err != nil {
+			return tb, fmt.Errorf("timebox.total: %s", err)
+		}
+		tb.total = d
+	}
+	for topic, budget := range v.GetStringMapString("timebox.topics") {
+		d, err := time.ParseDuration(budget)
+		if err != nil {
+			return tb, fmt.Errorf("timebox.topics.%s: %s", topic, err)
+		}
+		tb.budgets[strings.ToLower(topic)] = d
+	}
+	return tb, nil
+}
+
+// setBudgets changes the budgets, "45m" sets the total and "java=15m" the budget of a topic.
+func (tb *timebox) setBudgets(options []string) error {
+	if len(options) == 1 && strings.ToLower(options[0]) == "off" {
+		tb.total = 0
+		tb.budgets = make(map[string]time.Duration)
+		return nil
+	}
+	for _, option := range options {
+		topic, budget := "", option
+		if i := strings.Index(option, "="); i >= 0 {
+			topic, budget = strings.ToLower(option[:i]), option[i+1:]
+		}
+		d, err := time.ParseDuration(budget)
+		if err != nil || d <= 0 {
+			return newCommandError("'%s' is not a duration, e.g. 45m or java=15m", option)
+		}
+		if len(topic) == 0 {
+			tb.total = d
+		} else {
+			tb.budgets[topic] = d
+		}
+	}
+	return nil
+}
+
+// track charges the time since the last call to the topic that was selected, from now on
+// the time goes to topic.
+func (tb *timebox) track(topic string, now time.Time) {
+	if len(tb.current) > 0 {
+		tb.spent[tb.current] += now.Sub(tb.since)
+	}
+	if _, seen := tb.spent[topic]; !seen && len(topic) > 0 {
+		tb.spent[topic] = 0
+		tb.order = append(tb.order, topic)
+	}
+	tb.current = topic
+	tb.since = now
+}
+
+func (tb *timebox) spentOn(topic string, now time.Time) time.Duration {
+	spent := tb.spent[topic]
+	if topic == tb.current && len(topic) > 0 {
+		spent += now.Sub(tb.since)
+	}
+	return spent
+}
+
+// formatDuration prints a duration as minutes and seconds, e.g. 12:05.
+func formatDuration(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%02d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+// timeLeft is what the prompt shows about the time of the interview, empty without a total budget.
+func timeLeft(config *Config, now time.Time) string {
+	if !config.hasStarted || config.timebox.total == 0 {
+		return ""
+	}
+	left := config.timebox.total - now.Sub(config.interview.Date)
+	if left < 0 {
+		return formatDuration(left) + " over"
+	}
+	return formatDuration(left) + " left"
+}
+
+// paceInterview keeps track of the time spent on the selected topic and warns, once, when
+// a topic or the interview go over their budget.
+func paceInterview(config *Config, now time.Time) {
+	if !config.hasStarted {
+		return
+	}
+	tb := &config.timebox
+	if tb.current != config.selectedTopic {
+		tb.track(config.selectedTopic, now)
+	}
+
+	if budget, ok := tb.budgets[tb.current]; ok && !tb.warned[tb.current] {
+		if spent := tb.spentOn(tb.current, now); spent > budget {
+			tb.warned[tb.current] = true
+			printWithColorln(fmt.Sprintf("Time: %s is over its %s budget, %s spent on it.",
+				tb.current, formatDuration(budget), formatDuration(spent)), yellow, config)
+		}
+	}
+	if tb.total > 0 && !tb.warned[""] && now.Sub(config.interview.Date) > tb.total {
+		tb.warned[""] = true
+		printWithColorln(fmt.Sprintf("Time: the interview is over its %s budget.", formatDuration(tb.total)), red, config)
+	}
+}
+
+// printTimeSummary prints the planned and the actual time of every topic.
+func printTimeSummary(config *Config, now time.Time) {
+	tb := &config.timebox
+	tb.track(config.selectedTopic, now)
+
+	topics := append([]string{}, tb.order...)
+	budgeted := make([]string, 0)
+	for topic := range tb.budgets {
+		if _, seen := tb.spent[topic]; !seen {
+			budgeted = append(budgeted, topic)
+		}
+	}
+	sort.Strings(budgeted)
+	topics = append(topics, budgeted...)
+	if len(topics) == 0 {
+		return
+	}
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TOPIC\tPLANNED\tACTUAL\tDIFF")
+	for _, topic := range topics {
+		fmt.Fprintf(w, "%s\t%s\n", topic, timeColumns(tb.budgets[topic], tb.spent[topic]))
+	}
+	fmt.Fprintf(w, "total\t%s\n", timeColumns(tb.total, now.Sub(config.interview.Date)))
+	w.Flush()
+}
+
+func timeColumns(planned, actual time.Duration) string {
+	if planned == 0 {
+		return fmt.Sprintf("-\t%s\t-", formatDuration(actual))
+	}
+	sign := "+"
+	if actual < planned {
+		sign = "-"
+	}
+	return fmt.Sprintf("%s\t%s\t%s%s", formatDuration(planned), formatDuration(actual), sign, formatDuration(actual-planned))
+}
+
+func printTimebox(config *Config, now time.Time) {
+	tb := &config.timebox
+	if tb.total == 0 && len(tb.budgets) == 0 {
+		fmt.Println("There are no time budgets, e.g. timebox 45m java=15m sql=10m")
+		return
+	}
+	if tb.total > 0 {
+		fmt.Printf("Interview: ")
+		printWithColorf(config, "%s\n", green, formatDuration(tb.total))
+	}
+	topics := make([]string, 0, len(tb.budgets))
+	for topic := range tb.budgets {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	for _, topic := range topics {
+		fmt.Printf("%s: ", topic)
+		printWithColorf(config, "%s (%s spent)\n", green, formatDuration(tb.budgets[topic]), formatDuration(tb.spentOn(topic, now)))
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func Test_timeboxFrom(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	settings := "timebox:\n  total: 45m\n  topics:\n    Java: 15m\n    sql: 10m\n"
+	if err := v.ReadConfig(strings.NewReader(settings)); err != nil {
+		t.Fatal(err)
+	}
+	tb, err := timeboxFrom(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tb.total != 45*time.Minute || tb.budgets["java"] != 15*time.Minute || tb.budgets["sql"] != 10*time.Minute {
+		t.Errorf("got total=[%s] budgets=[%v]", tb.total, tb.budgets)
+	}
+
+	v.Set("timebox.total", "soon")
+	if _, err := timeboxFrom(v); err == nil {
+		t.Errorf("want error")
+	}
+}
+
+func Test_timebox_setBudgets(t *testing.T) {
+	tb := newTimebox()
+	if err := tb.setBudgets([]string{"60m", "Java=20m"}); err != nil {
+		t.Fatal(err)
+	}
+	if tb.total != time.Hour || tb.budgets["java"] != 20*time.Minute {
+		t.Errorf("got total=[%s] budgets=[%v]", tb.total, tb.budgets)
+	}
+	for _, option := range []string{"java=", "later", "-5m"} {
+		if err := tb.setBudgets([]string{option}); err == nil {
+			t.Errorf("option=[%s]: want error", option)
+		}
+	}
+	if err := tb.setBudgets([]string{"off"}); err != nil || tb.total != 0 || len(tb.budgets) != 0 {
+		t.Errorf("got total=[%s] budgets=[%v] error=[%v]", tb.total, tb.budgets, err)
+	}
+}
+
+func Test_timebox_track(t *testing.T) {
+	start := time.Date(2020, 6, 26, 10, 0, 0, 0, time.UTC)
+	tb := newTimebox()
+	tb.track("java", start)
+	tb.track("sql", start.Add(10*time.Minute))
+	tb.track("java", start.Add(15*time.Minute))
+
+	now := start.Add(20 * time.Minute)
+	if got := tb.spentOn("java", now); got != 15*time.Minute {
+		t.Errorf("java: got=[%s], want=[15m]", got)
+	}
+	if got := tb.spentOn("sql", now); got != 5*time.Minute {
+		t.Errorf("sql: got=[%s], want=[5m]", got)
+	}
+	if !EqualTopics(tb.order, []string{"java", "sql"}) {
+		t.Errorf("got order=[%v]", tb.order)
+	}
+}
+
+func Test_paceInterview(t *testing.T) {
+	start := time.Date(2020, 6, 26, 10, 0, 0, 0, time.UTC)
+	config := NewConfig()
+	config.hasStarted = true
+	config.interview.Date = start
+	config.selectedTopic = "java"
+	config.timebox.total = 30 * time.Minute
+	config.timebox.budgets["java"] = 10 * time.Minute
+
+	paceInterview(&config, start)
+	if got, want := timeLeft(&config, start.Add(5*time.Minute)), "25:00 left"; got != want {
+		t.Errorf("got=[%s], want=[%s]", got, want)
+	}
+	paceInterview(&config, start.Add(11*time.Minute))
+	if !config.timebox.warned["java"] {
+		t.Errorf("java is over its budget and it wasn't warned")
+	}
+	if got, want := timeLeft(&config, start.Add(32*time.Minute+5*time.Second)), "02:05 over"; got != want {
+		t.Errorf("got=[%s], want=[%s]", got, want)
+	}
+}
+
+func Test_timeColumns(t *testing.T) {
+	type test struct {
+		planned, actual time.Duration
+		want            string
+	}
+	tests := []test{
+		{planned: 15 * time.Minute, actual: 18 * time.Minute, want: "15:00\t18:00\t+03:00"},
+		{planned: 15 * time.Minute, actual: 12*time.Minute + 30*time.Second, want: "15:00\t12:30\t-02:30"},
+		{planned: 0, actual: 5 * time.Minute, want: "-\t05:00\t-"},
+	}
+	for _, tt := range tests {
+		if got := timeColumns(tt.planned, tt.actual); got != tt.want {
+			t.Errorf("got=[%q], want=[%q]", got, tt.want)
+		}
+	}
+}
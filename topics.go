@@ -0,0 +1,231 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// topicActions are the subcommands of topic, with the number of arguments they take.
+var topicActions = map[string]struct {
+	usage   string
+	minArgs int
+	maxArgs int
+	run     func(args []string, config *Config, db *sql.DB) error
+}{
+	"add":      {usage: "topic add <name> [description]", minArgs: 1, maxArgs: unlimitedArgs, run: addTopic},
+	"rename":   {usage: "topic rename <name> <new-name>", minArgs: 2, maxArgs: 2, run: renameTopic},
+	"describe": {usage: "topic describe <name> <description>", minArgs: 2, maxArgs: unlimitedArgs, run: describeTopic},
+	"merge":    {usage: "topic merge <from> <into> [--yes]", minArgs: 2, maxArgs: 3, run: mergeTopic},
+	"delete":   {usage: "topic delete <name>", minArgs: 1, maxArgs: 1, run: deleteTopic},
+}
+
+func manageTopics(args []string, config *Config, db *sql.DB) error {
+	action, ok := topicActions[strings.ToLower(args[0])]
+	if !ok {
+		return newCommandError("unknown action '%s', use add, rename, describe, merge or delete", args[0])
+	}
+	rest := args[1:]
+	if len(rest) < action.minArgs || (action.maxArgs != unlimitedArgs && len(rest) > action.maxArgs) {
+		return newCommandError("usage: %s", action.usage)
+	}
+	return action.run(rest, config, db)
+}
+
+// findTopic looks a topic up by its name, ignoring case.
+func findTopic(name string, db *sql.DB) (Topic, error) {
+	topics, err := getTopics(db)
+	if err != nil {
+		return Topic{}, err
+	}
+	for _, topic := range topics {
+		if strings.EqualFold(topic.Topic, name) {
+			return topic, nil
+		}
+	}
+	return Topic{}, newCommandError("topic '%s' not found", name)
+}
+
+func validTopicName(name string, db *sql.DB) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if len(name) == 0 || strings.ContainsAny(name, " \t/") {
+		return "", newCommandError("'%s' is not a valid topic name, it can't be empty nor have spaces or '/'", name)
+	}
+	topics, err := getTopics(db)
+	if err != nil {
+		return "", err
+	}
+	for _, topic := range topics {
+		if strings.EqualFold(topic.Topic, name) {
+			return "", newCommandError("topic '%s' already exists", name)
+		}
+	}
+	return name, nil
+}
+
+// inInterview refuses to change a topic that the running interview has loaded.
+func inInterview(topic string, config *Config) error {
+	if _, loaded := config.interview.Topics[topic]; loaded && config.hasStarted {
+		return newCommandError("topic '%s' is part of the running interview, finish it first", topic)
+	}
+	return nil
+}
+
+func addTopic(args []string, config *Config, db *sql.DB) error {
+	name, err := validTopicName(args[0], db)
+	if err != nil {
+		return err
+	}
+	if err := saveTopic(name, strings.Join(args[1:], " "), db); err != nil {
+		return err
+	}
+	printWithColorln(fmt.Sprintf("Topic '%s' created.", name), green, config)
+	return nil
+}
+
+func renameTopic(args []string, config *Config, db *sql.DB) error {
+	topic, err := findTopic(args[0], db)
+	if err != nil {
+		return err
+	}
+	name, err := validTopicName(args[1], db)
+	if err != nil {
+		return err
+	}
+	if err := updateTopicName(topic.ID, name, db); err != nil {
+		return err
+	}
+
+	if qs, loaded := config.interview.Topics[topic.Topic]; loaded {
+		delete(config.interview.Topics, topic.Topic)
+		config.interview.Topics[name] = qs
+	}
+	if config.selectedTopic == topic.Topic {
+		config.selectedTopic = name
+	}
+	printWithColorln(fmt.Sprintf("Topic '%s' renamed to '%s'.", topic.Topic, name), green, config)
+	return nil
+}
+
+func describeTopic(args []string, config *Config, db *sql.DB) error {
+	topic, err := findTopic(args[0], db)
+	if err != nil {
+		return err
+	}
+	if err := updateTopicDescription(topic.ID, strings.Join(args[1:], " "), db); err != nil {
+		return err
+	}
+	printWithColorln(fmt.Sprintf("Topic '%s' described.", topic.Topic), green, config)
+	return nil
+}
+
+// mergeTopic moves every question of a topic to another one and deletes the first one.
+func mergeTopic(args []string, config *Config, db *sql.DB) error {
+	args, yes := assumeYes(args)
+	if len(args) != 2 {
+		return newCommandError("usage: topic merge <from> <into> [--yes]")
+	}
+	from, err := findTopic(args[0], db)
+	if err != nil {
+		return err
+	}
+	into, err := findTopic(args[1], db)
+	if err != nil {
+		return err
+	}
+	if from.ID == into.ID {
+		return newCommandError("a topic can't be merged into itself")
+	}
+	for _, topic := range []string{from.Topic, into.Topic} {
+		if err := inInterview(topic, config); err != nil {
+			return err
+		}
+	}
+
+	questions, answers, err := getTopicUsage(from.ID, db)
+	if err != nil {
+		return err
+	}
+	confirmed, err := confirm(fmt.Sprintf("Move %d questions (%d answers) from '%s' to '%s' and delete '%s'? [y/N] ",
+		questions, answers, from.Topic, into.Topic, from.Topic), yes, config)
+	if err != nil || !confirmed {
+		return err
+	}
+
+	if err := mergeTopics(from.ID, into.ID, db); err != nil {
+		return err
+	}
+	delete(config.interview.Topics, from.Topic)
+	delete(config.interview.Topics, into.Topic)
+	if config.selectedTopic == from.Topic || config.selectedTopic == into.Topic {
+		config.selectedTopic = ""
+	}
+	printWithColorln(fmt.Sprintf("%d questions moved from '%s' to '%s', '%s' was deleted.",
+		questions, from.Topic, into.Topic, from.Topic), green, config)
+	return nil
+}
+
+// deleteTopic deletes a topic without questions, the questions of a topic have to be
+// merged into another topic first so their answers keep a topic.
+func deleteTopic(args []string, config *Config, db *sql.DB) error {
+	topic, err := findTopic(args[0], db)
+	if err != nil {
+		return err
+	}
+	if err := inInterview(topic.Topic, config); err != nil {
+		return err
+	}
+	questions, answers, err := getTopicUsage(topic.ID, db)
+	if err != nil {
+		return err
+	}
+	if questions > 0 {
+		return newCommandError("topic '%s' has %d questions with %d answers, merge it into another topic instead: topic merge %s <into>",
+			topic.Topic, questions, answers, topic.Topic)
+	}
+	if err := removeTopic(topic.ID, db); err != nil {
+		return err
+	}
+	delete(config.interview.Topics, topic.Topic)
+	if config.selectedTopic == topic.Topic {
+		config.selectedTopic = ""
+	}
+	printWithColorln(fmt.Sprintf("Topic '%s' deleted.", topic.Topic), green, config)
+	return nil
+}
+
+// assumeYes removes --yes from the arguments of a command, it tells that the command
+// was confirmed beforehand.
+func assumeYes(args []string) ([]string, bool) {
+	rest := make([]string, 0, len(args))
+	yes := false
+	for _, arg := range args {
+		if arg == "--yes" {
+			yes = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, yes
+}
+
+// confirm asks a yes/no question before a destructive action. Scripts can't answer it,
+// so they have to confirm the action beforehand with --yes.
+func confirm(question string, yes bool, config *Config) (bool, error) {
+	if yes {
+		return true, nil
+	}
+	if !config.input.interactive() {
+		return false, newCommandError("the input is not interactive, add --yes to confirm: %s", strings.TrimSpace(question))
+	}
+	answer, err := config.input.readLine(question)
+	if err != nil {
+		return false, err
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer == "y" || answer == "yes" {
+		return true, nil
+	}
+	printWithColorln("Cancelled.", yellow, config)
+	return false, nil
+}
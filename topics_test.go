@@ -0,0 +1,89 @@
+package main
+
+import "testing"
+
+func Test_manageTopics_usage(t *testing.T) {
+	config := NewConfig()
+	invalid := [][]string{
+		{"fly", "java"},
+		{"add"},
+		{"rename", "java"},
+		{"merge", "java", "jvm", "j2ee"},
+		{"delete"},
+		{"describe", "java"},
+	}
+	for _, args := range invalid {
+		err := manageTopics(args, &config, nil)
+		if _, ok := err.(*commandError); !ok {
+			t.Errorf("args=%v: got=[%v], want a command error", args, err)
+		}
+	}
+}
+
+func Test_validTopicName_invalid(t *testing.T) {
+	for _, name := range []string{"", "  ", "spring boot", "java/jvm"} {
+		if _, err := validTopicName(name, nil); err == nil {
+			t.Errorf("name=[%s]: want error", name)
+		}
+	}
+}
+
+func Test_inInterview(t *testing.T) {
+	config := undoTestConfig()
+	if err := inInterview("java", &config); err == nil {
+		t.Errorf("java is loaded in the running interview, want error")
+	}
+	if err := inInterview("python", &config); err != nil {
+		t.Errorf("python is not loaded, got=[%v]", err)
+	}
+	config.hasStarted = false
+	if err := inInterview("java", &config); err != nil {
+		t.Errorf("there is no running interview, got=[%v]", err)
+	}
+}
+
+func Test_confirm(t *testing.T) {
+	type test struct {
+		lines []string
+		want  bool
+	}
+	tests := []test{
+		{lines: []string{"y"}, want: true},
+		{lines: []string{" YES "}, want: true},
+		{lines: []string{""}, want: false},
+		{lines: []string{"n"}, want: false},
+	}
+	for _, tt := range tests {
+		config := NewConfig()
+		config.input = &typedLines{lines: tt.lines}
+		got, err := confirm("Merge? ", false, &config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("lines=%v: got=[%t], want=[%t]", tt.lines, got, tt.want)
+		}
+	}
+}
+
+func Test_confirm_notInteractive(t *testing.T) {
+	config := NewConfig()
+	config.input = &scriptConsole{}
+	if _, err := confirm("Merge? ", false, &config); err == nil {
+		t.Errorf("a script can't confirm without --yes, want error")
+	}
+	got, err := confirm("Merge? ", true, &config)
+	if err != nil || !got {
+		t.Errorf("--yes confirms, got=[%t] err=[%v]", got, err)
+	}
+}
+
+func Test_assumeYes(t *testing.T) {
+	args, yes := assumeYes([]string{"j2ee", "--yes", "java"})
+	if !yes || len(args) != 2 || args[0] != "j2ee" || args[1] != "java" {
+		t.Errorf("got args=%v yes=[%t]", args, yes)
+	}
+	if _, yes := assumeYes([]string{"j2ee", "java"}); yes {
+		t.Errorf("--yes was not given")
+	}
+}
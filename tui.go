@@ -0,0 +1,441 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/muesli/termenv"
+)
+
+// tuiKeys maps the single keys of the full-screen mode to the REPL commands they run.
+var tuiKeys = map[rune]string{
+	'y': "y",
+	'n': "n",
+	'm': "meh",
+	'l': "next",
+	'h': "prev",
+	'+': "+",
+	'-': "-",
+	'=': "=",
+	'1': "ap",
+	'2': "pa",
+	'3': "sr",
+	'u': "undo",
+	'r': "redo",
+}
+
+var tuiSpecialKeys = map[tcell.Key]string{
+	tcell.KeyRight: "next",
+	tcell.KeyLeft:  "prev",
+	tcell.KeyUp:    "+",
+	tcell.KeyDown:  "-",
+}
+
+// tuiTick is how often the full-screen mode is redrawn to keep the time left current.
+const tuiTick = 15 * time.Second
+
+const tuiHelp = "y ok  n wrong  m meh  ←/h prev  →/l next  +/- level  1/2/3 set level  = ignore levels  u/r undo/redo  a answer  c comment (ctrl-j new line)  q back to prompt"
+
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
+
+// tui is the full-screen mode to run an interview, it works on the same Config as the REPL.
+type tui struct {
+	screen       tcell.Screen
+	config       *Config
+	db           *sql.DB
+	showAnswer   bool
+	editing      bool
+	commentDraft []rune
+	status       string
+	failed       bool
+	styles       tuiStyles
+}
+
+// tuiStyles are the styles of the full-screen mode, with the colors of the theme.
+type tuiStyles struct {
+	header   tcell.Style
+	title    tcell.Style
+	text     tcell.Style
+	faint    tcell.Style
+	question tcell.Style
+	answer   tcell.Style
+	editing  tcell.Style
+	current  tcell.Style
+	status   tcell.Style
+	err      tcell.Style
+}
+
+func newTUIStyles(config *Config) tuiStyles {
+	fg := func(key string) tcell.Style {
+		return tcell.StyleDefault.Foreground(tuiColor(config, key))
+	}
+	return tuiStyles{
+		header:   tcell.StyleDefault.Reverse(true),
+		title:    fg(blue).Bold(true),
+		text:     tcell.StyleDefault,
+		faint:    tcell.StyleDefault.Dim(true),
+		question: fg(gray),
+		answer:   fg(green),
+		editing:  tcell.StyleDefault.Underline(true),
+		current:  fg(cyan).Bold(true),
+		status:   fg(magenta),
+		err:      fg(red),
+	}
+}
+
+// tuiColor is the color of the theme for a color key, the terminal's own color when
+// colors are off.
+func tuiColor(config *Config, key string) tcell.Color {
+	if config.colorProfile == termenv.Ascii {
+		return tcell.ColorDefault
+	}
+	value := config.theme.colors[key]
+	if n, err := strconv.Atoi(value); err == nil {
+		return tcell.PaletteColor(n)
+	}
+	return tcell.GetColor(value)
+}
+
+func runTUI(config *Config, db *sql.DB) error {
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return err
+	}
+	if err := screen.Init(); err != nil {
+		return err
+	}
+	defer screen.Fini()
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(tuiTick)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				screen.PostEvent(tcell.NewEventInterrupt(nil))
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	ui := &tui{screen: screen, config: config, db: db, status: "Interview with " + config.interview.Interviewee,
+		styles: newTUIStyles(config)}
+	for {
+		ui.pace(time.Now())
+		ui.draw()
+		switch ev := screen.PollEvent().(type) {
+		case *tcell.EventResize:
+			screen.Sync()
+		case *tcell.EventKey:
+			if ui.editing {
+				ui.editComment(ev)
+				continue
+			}
+			if quit := ui.handleKey(ev); quit {
+				return nil
+			}
+		}
+	}
+}
+
+func (ui *tui) handleKey(ev *tcell.EventKey) bool {
+	if cmd, ok := tuiSpecialKeys[ev.Key()]; ok {
+		ui.run(cmd)
+		return false
+	}
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyCtrlC:
+		return true
+	case tcell.KeyRune:
+	default:
+		return false
+	}
+
+	switch ev.Rune() {
+	case 'q':
+		return true
+	case 'a':
+		ui.showAnswer = !ui.showAnswer
+	case 'c':
+		q, ok := currentQuestion(ui.config)
+		if !ok {
+			ui.failed = true
+			ui.status = "There are no questions to comment on."
+			return false
+		}
+		ui.editing = true
+		ui.commentDraft = []rune(q.Comment)
+	default:
+		if cmd, ok := tuiKeys[ev.Rune()]; ok {
+			ui.run(cmd)
+		}
+	}
+	return false
+}
+
+func (ui *tui) editComment(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape:
+		ui.editing = false
+		ui.status = "Comment discarded."
+	case tcell.KeyEnter:
+		ui.editing = false
+		ui.run("cmt", string(ui.commentDraft))
+	case tcell.KeyCtrlJ:
+		ui.commentDraft = append(ui.commentDraft, '\n')
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if len(ui.commentDraft) > 0 {
+			ui.commentDraft = ui.commentDraft[:len(ui.commentDraft)-1]
+		}
+	case tcell.KeyRune:
+		ui.commentDraft = append(ui.commentDraft, ev.Rune())
+	}
+}
+
+// run runs a REPL command, whatever it prints is shown in the status line.
+func (ui *tui) run(name string, args ...string) {
+	ui.failed = true
+	spec, ok := commands.lookup(name)
+	if !ok {
+		ui.status = fmt.Sprintf("unknown command '%s'", name)
+		return
+	}
+	if err := spec.validateArgs(args); err != nil {
+		ui.status = err.Error()
+		return
+	}
+	out, err := captureOutput(func() error {
+		return runCommand(spec, args, ui.config, ui.db)
+	})
+	if err := saveSession(ui.config, sessionPath()); err != nil {
+		logError(name, err, ui.config)
+	}
+	if err != nil {
+		ui.status = err.Error()
+		return
+	}
+	ui.failed = false
+	ui.status = lastLine(out)
+}
+
+// pace runs the time checks of the REPL, their warnings are shown in the status line.
+func (ui *tui) pace(now time.Time) {
+	out, _ := captureOutput(func() error {
+		paceInterview(ui.config, now)
+		return nil
+	})
+	if len(strings.TrimSpace(out)) > 0 {
+		ui.failed = true
+		ui.status = lastLine(out)
+	}
+}
+
+// captureOutput runs f and returns what it wrote to stdout, without the color codes.
+func captureOutput(f func() error) (string, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	captured := make(chan string)
+	go func() {
+		var b bytes.Buffer
+		io.Copy(&b, r)
+		r.Close()
+		captured <- b.String()
+	}()
+
+	err = f()
+	w.Close()
+	os.Stdout = stdout
+	return ansiEscape.ReplaceAllString(<-captured, ""), err
+}
+
+func lastLine(out string) string {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// levelProgress tallies the results of the questions of every level.
+func levelProgress(questions []Question) map[Level]*resultTally {
+	progress := make(map[Level]*resultTally)
+	for _, lvl := range []Level{AssociateOrProgrammer, ProgrammerAnalyst, SrProgrammer} {
+		progress[lvl] = &resultTally{}
+	}
+	for _, q := range questions {
+		if tally, ok := progress[q.Level]; ok {
+			tally.add(q.Result)
+		}
+	}
+	return progress
+}
+
+// wrapText splits text in lines of at most width runes, breaking on spaces when possible.
+func wrapText(text string, width int) []string {
+	if width <= 0 {
+		return []string{}
+	}
+	lines := make([]string, 0)
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := make([]rune, 0, width)
+		for _, word := range strings.Fields(paragraph) {
+			w := []rune(word)
+			for len(w) > width {
+				if len(line) > 0 {
+					lines = append(lines, string(line))
+					line = line[:0]
+				}
+				lines = append(lines, string(w[:width]))
+				w = w[width:]
+			}
+			switch {
+			case len(line) == 0:
+				line = append(line, w...)
+			case len(line)+1+len(w) <= width:
+				line = append(append(line, ' '), w...)
+			default:
+				lines = append(lines, string(line))
+				line = append(line[:0], w...)
+			}
+		}
+		lines = append(lines, string(line))
+	}
+	return lines
+}
+
+// wrapDraft splits the comment being typed in lines of at most width runes, keeping
+// every space so the cursor is drawn where the next rune goes.
+func wrapDraft(draft string, width int) []string {
+	if width <= 0 {
+		return []string{}
+	}
+	lines := make([]string, 0)
+	for _, paragraph := range strings.Split(draft, "\n") {
+		line := []rune(paragraph)
+		for len(line) > width {
+			lines = append(lines, string(line[:width]))
+			line = line[width:]
+		}
+		lines = append(lines, string(line))
+	}
+	return lines
+}
+
+func (ui *tui) draw() {
+	s := ui.screen
+	s.Clear()
+	width, height := s.Size()
+	config := ui.config
+	styles := ui.styles
+
+	title := styles.title
+	faint := styles.faint
+
+	mode := config.levels[config.levelIndex].String()
+	if config.ignoreLevelChecking {
+		mode = "ignoring levels"
+	}
+	header := fmt.Sprintf(" %s | /%s | %s ", config.interview.Interviewee, config.selectedTopic, mode)
+	if left := timeLeft(config, time.Now()); len(left) > 0 {
+		header += "| " + left + " "
+	}
+	ui.drawLine(0, 0, width, styles.header, header+strings.Repeat(" ", width))
+
+	leftWidth := width * 2 / 3
+	rightX := leftWidth + 2
+	y := 2
+
+	ui.drawLine(1, y, leftWidth, title, "Question")
+	y++
+	q, ok := currentQuestion(config)
+	if !ok {
+		y = ui.drawText(1, y, leftWidth-1, faint, "There are no questions for this level.")
+	} else {
+		y = ui.drawText(1, y, leftWidth-1, styles.question, q.String())
+	}
+	y++
+
+	if ok && ui.showAnswer {
+		ui.drawLine(1, y, leftWidth, title, "Reference answer")
+		y++
+		answer := q.Answer
+		if len(strings.TrimSpace(answer)) == 0 {
+			answer = "(no reference answer)"
+		}
+		y = ui.drawText(1, y, leftWidth-1, styles.answer, answer)
+		y++
+	}
+
+	if ok {
+		ui.drawLine(1, y, leftWidth, title, "Comment")
+		y++
+		if ui.editing {
+			for _, line := range wrapDraft(string(ui.commentDraft)+"_", leftWidth-1) {
+				ui.drawLine(1, y, leftWidth-1, styles.editing, line)
+				y++
+			}
+		} else {
+			ui.drawText(1, y, leftWidth-1, faint, q.Comment)
+		}
+	}
+
+	y = 2
+	ui.drawLine(rightX, y, width-rightX, title, "Progress")
+	y++
+	progress := levelProgress(config.interview.Topics[config.selectedTopic])
+	for i, lvl := range config.levels {
+		style := styles.text
+		marker := "  "
+		if i == config.levelIndex && !config.ignoreLevelChecking {
+			style = styles.current
+			marker = "> "
+		}
+		tally := progress[lvl]
+		answered := tally.total() - tally.notAnswered
+		ui.drawLine(rightX, y, width-rightX, style, fmt.Sprintf("%s%s %d/%d", marker, lvl, answered, tally.total()))
+		y++
+		ui.drawLine(rightX, y, width-rightX, faint,
+			fmt.Sprintf("    ok %d  wrong %d  meh %d", tally.ok, tally.wrong, tally.neutral))
+		y++
+	}
+
+	status := styles.status
+	if ui.failed {
+		status = styles.err
+	}
+	ui.drawLine(0, height-2, width, status, " "+ui.status)
+	ui.drawLine(0, height-1, width, faint, " "+tuiHelp)
+	s.Show()
+}
+
+func (ui *tui) drawLine(x, y, width int, style tcell.Style, text string) {
+	col := 0
+	for _, r := range text {
+		if col >= width {
+			break
+		}
+		ui.screen.SetContent(x+col, y, r, nil, style)
+		col++
+	}
+}
+
+// drawText draws the wrapped text and returns the row that follows it.
+func (ui *tui) drawText(x, y, width int, style tcell.Style, text string) int {
+	for _, line := range wrapText(text, width) {
+		ui.drawLine(x, y, width, style, line)
+		y++
+	}
+	return y
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/muesli/termenv"
+)
+
+func Test_wrapText(t *testing.T) {
+	type test struct {
+		text  string
+		width int
+		want  []string
+	}
+
+	tests := []test{
+		{text: "What is an immutable class?", width: 12, want: []string{"What is an", "immutable", "class?"}},
+		{text: "short", width: 12, want: []string{"short"}},
+		{text: "abcdefghij", width: 4, want: []string{"abcd", "efgh", "ij"}},
+		{text: "one\ntwo", width: 10, want: []string{"one", "two"}},
+		{text: "", width: 10, want: []string{""}},
+		{text: "anything", width: 0, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		if got := wrapText(tt.text, tt.width); !EqualTopics(got, tt.want) {
+			t.Errorf("got=[%q], want=[%q]", got, tt.want)
+		}
+	}
+}
+
+func Test_wrapDraft(t *testing.T) {
+	got := wrapDraft("knows  joins\nno idea_", 5)
+	want := []string{"knows", "  joi", "ns", "no id", "ea_"}
+	if !EqualTopics(got, want) {
+		t.Errorf("got=%q, want=%q", got, want)
+	}
+}
+
+func Test_tuiColor(t *testing.T) {
+	config := NewConfig()
+	config.colorProfile = termenv.TrueColor
+	config.theme = themes["high-contrast"]
+	if got := tuiColor(&config, red); got != tcell.PaletteColor(9) {
+		t.Errorf("got=[%v], want palette color 9", got)
+	}
+	config.theme = themes["dark"]
+	if got := tuiColor(&config, red); got != tcell.GetColor("#E88388") {
+		t.Errorf("got=[%v], want=[#E88388]", got)
+	}
+	config.colorProfile = termenv.Ascii
+	if got := tuiColor(&config, red); got != tcell.ColorDefault {
+		t.Errorf("colors are off, got=[%v]", got)
+	}
+}
+
+func Test_levelProgress(t *testing.T) {
+	questions := []Question{
+		Question{ID: 1, Level: AssociateOrProgrammer, Result: OK},
+		Question{ID: 2, Level: AssociateOrProgrammer},
+		Question{ID: 3, Level: SrProgrammer, Result: Wrong},
+	}
+
+	progress := levelProgress(questions)
+	if *progress[AssociateOrProgrammer] != (resultTally{ok: 1, notAnswered: 1}) {
+		t.Errorf("got=[%+v]", *progress[AssociateOrProgrammer])
+	}
+	if progress[ProgrammerAnalyst].total() != 0 {
+		t.Errorf("got=[%+v]", *progress[ProgrammerAnalyst])
+	}
+	if *progress[SrProgrammer] != (resultTally{wrong: 1}) {
+		t.Errorf("got=[%+v]", *progress[SrProgrammer])
+	}
+}
+
+func Test_tuiKeys(t *testing.T) {
+	for key, name := range tuiKeys {
+		if _, ok := commands.lookup(name); !ok {
+			t.Errorf("key '%c' runs '%s' which is not a command", key, name)
+		}
+	}
+	for key, name := range tuiSpecialKeys {
+		if _, ok := commands.lookup(name); !ok {
+			t.Errorf("key %v runs '%s' which is not a command", key, name)
+		}
+	}
+}
+
+func Test_captureOutput(t *testing.T) {
+	config := NewConfig()
+	out, err := captureOutput(func() error {
+		printWithColorln("Level is now: ProgrammerAnalyst", yellow, &config)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := lastLine(out); got != "Level is now: ProgrammerAnalyst" {
+		t.Errorf("got=[%q]", got)
+	}
+}
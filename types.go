@@ -3,6 +3,7 @@ package main
 import (
 	"database/sql"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/muesli/termenv"
@@ -12,6 +13,8 @@ import (
 type Config struct {
 	selectedTopic          string
 	ps1                    string
+	promptTemplate         string
+	dbName                 string
 	hasStarted             bool
 	questionIndex          int
 	topicQuestionsLevel    Level
@@ -20,21 +23,33 @@ type Config struct {
 	individualLevelIndexes []int
 	levels                 [3]Level
 	colorProfile           termenv.Profile
+	theme                  theme
 	interview              Interview
 	intervieweeID          int
-	comment                string
+	shuffle                bool
+	seed                   int64
+	drawCount              int
+	adaptive               adaptiveRules
+	okStreak               int
+	wrongStreak            int
+	plans                  map[string]interviewPlan
+	plan                   *interviewPlan
+	planStep               int
+	timebox                timebox
+	scoring                scoreWeights
+	history                undoHistory
+	input                  lineReader
+	errorLog               *log.Logger
 }
 
-// Command ...
-type Command int
-
 // Question ...
 type Question struct {
-	ID     int
-	Q      string
-	Answer string
-	Result Result
-	Level  Level
+	ID      int
+	Q       string
+	Answer  string
+	Result  Result
+	Level   Level
+	Comment string
 }
 
 // ResultCount ...
@@ -61,18 +76,22 @@ type Interview struct {
 
 // Topic ...
 type Topic struct {
-	ID    int    `json:"id"`
-	Topic string `json:"topic"`
+	ID          int    `json:"id"`
+	Topic       string `json:"topic"`
+	Description string `json:"description"`
 }
 
 // AnswerView ...
 type AnswerView struct {
-	ID       int
-	Question string
-	Result   int
-	Comment  sql.NullString
-	Topic    string
-	Title    string
+	ID         int
+	QuestionID int
+	Question   string
+	Answer     string
+	Result     int
+	Comment    sql.NullString
+	Topic      string
+	Level      Level
+	Title      string
 }
 
 func (av AnswerView) String() string {
@@ -85,11 +104,41 @@ func (av AnswerView) String() string {
 		av.Question, Result(av.Result), av.Topic, av.Title)
 }
 
+// QuestionUsage is how much a question has been used, LastAsked is the date of the
+// last interview that asked it.
+type QuestionUsage struct {
+	ID          int
+	Topic       string
+	Level       Level
+	EmptyAnswer bool
+	Asked       int
+	LastAsked   sql.NullString
+}
+
+// InterviewEvent is something that happened during an interview, e.g. an automatic level change.
+type InterviewEvent struct {
+	Event     string
+	Detail    string
+	CreatedAt string
+}
+
+func (e InterviewEvent) String() string {
+	return fmt.Sprintf("%s [%s] %s", e.CreatedAt, e.Event, e.Detail)
+}
+
 // CandidateView ...
 type CandidateView struct {
-	ID   int
-	Name string
-	Date string
+	ID           int
+	Name         string
+	Date         string
+	Email        string
+	Position     string
+	Seniority    string
+	Source       string
+	Notes        string
+	Deleted      bool
+	QuestionSeed sql.NullInt64
+	QuestionDraw sql.NullInt64
 }
 
 func (can CandidateView) String() string {
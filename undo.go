@@ -0,0 +1,255 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// undoKind tells what a command changes, and so what has to be saved to undo it.
+type undoKind int
+
+const (
+	noUndo undoKind = iota
+	// undoCursor commands move around the interview: navigation and level changes.
+	undoCursor
+	// undoAnswer commands also change the answer of the current question.
+	undoAnswer
+)
+
+// cursor is where the interview is at.
+type cursor struct {
+	selectedTopic          string
+	questionIndex          int
+	levelIndex             int
+	individualLevelIndexes []int
+	ignoreLevelChecking    bool
+	okStreak               int
+	wrongStreak            int
+	planStep               int
+}
+
+// answerState is the answer of a question as it is persisted, exists is false when
+// the answer table has no row for it.
+type answerState struct {
+	topic      string
+	questionID int
+	exists     bool
+	result     Result
+	comment    sql.NullString
+}
+
+// snapshot is the interview before or after a command, answers are restored in order.
+type snapshot struct {
+	cursor  cursor
+	answers []*answerState
+}
+
+// savedEvent is an interview_event row written by a command, e.g. an adaptive level
+// change, it is deleted when the command is undone.
+type savedEvent struct {
+	id     int
+	event  string
+	detail string
+}
+
+type undoEntry struct {
+	command string
+	before  snapshot
+	after   snapshot
+	events  []savedEvent
+}
+
+// undoHistory holds the commands that can be undone and the undone ones that can be redone,
+// events collects the events saved by the command being run.
+type undoHistory struct {
+	undo   []undoEntry
+	redo   []undoEntry
+	events []savedEvent
+}
+
+func (h *undoHistory) push(entry undoEntry) {
+	h.undo = append(h.undo, entry)
+	if len(h.undo) > maxUndoEntries {
+		h.undo = h.undo[len(h.undo)-maxUndoEntries:]
+	}
+	h.redo = nil
+}
+
+func saveCursor(config *Config) cursor {
+	return cursor{
+		selectedTopic:          config.selectedTopic,
+		questionIndex:          config.questionIndex,
+		levelIndex:             config.levelIndex,
+		individualLevelIndexes: append([]int{}, config.individualLevelIndexes...),
+		ignoreLevelChecking:    config.ignoreLevelChecking,
+		okStreak:               config.okStreak,
+		wrongStreak:            config.wrongStreak,
+		planStep:               config.planStep,
+	}
+}
+
+func restoreCursor(c cursor, config *Config) {
+	config.selectedTopic = c.selectedTopic
+	config.questionIndex = c.questionIndex
+	config.levelIndex = c.levelIndex
+	config.individualLevelIndexes = append([]int{}, c.individualLevelIndexes...)
+	config.ignoreLevelChecking = c.ignoreLevelChecking
+	config.okStreak = c.okStreak
+	config.wrongStreak = c.wrongStreak
+	config.planStep = c.planStep
+}
+
+func saveAnswerState(topic string, questionID int, config *Config, db *sql.DB) (*answerState, error) {
+	state := &answerState{topic: topic, questionID: questionID}
+	var err error
+	state.exists, state.result, state.comment, err = getAnswer(config.intervieweeID, questionID, db)
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// restoreAnswerState puts the answer back as it was persisted, the row is deleted when
+// it didn't exist.
+func restoreAnswerState(state *answerState, config *Config, db *sql.DB) error {
+	if state.exists {
+		if err := restoreAnswer(config.intervieweeID, state.questionID, state.result, state.comment, db); err != nil {
+			return err
+		}
+	} else if err := deleteAnswer(config.intervieweeID, state.questionID, db); err != nil {
+		return err
+	}
+
+	qs := config.interview.Topics[state.topic]
+	for i, q := range qs {
+		if q.ID == state.questionID {
+			qs[i].Result = 0
+			if state.exists {
+				qs[i].Result = state.result
+			}
+			qs[i].Comment = state.comment.String
+			break
+		}
+	}
+	return nil
+}
+
+// runUndoable runs a command saving what it changes, so it can be undone.
+func runUndoable(spec *commandSpec, args []string, config *Config, db *sql.DB) error {
+	entry := undoEntry{command: strings.TrimSpace(spec.names[0] + " " + strings.Join(args, " "))}
+	entry.before.cursor = saveCursor(config)
+	config.history.events = nil
+
+	var q Question
+	var hasQuestion bool
+	if spec.undo == undoAnswer {
+		if q, hasQuestion = currentQuestion(config); hasQuestion {
+			state, err := saveAnswerState(config.selectedTopic, q.ID, config, db)
+			if err != nil {
+				return err
+			}
+			entry.before.answers = []*answerState{state}
+		}
+	}
+
+	if err := spec.handler(args, config, db); err != nil {
+		return err
+	}
+
+	entry.after.cursor = saveCursor(config)
+	entry.events, config.history.events = config.history.events, nil
+	if hasQuestion {
+		state, err := saveAnswerState(entry.before.answers[0].topic, q.ID, config, db)
+		if err != nil {
+			return err
+		}
+		entry.after.answers = []*answerState{state}
+	}
+	config.history.push(entry)
+	return nil
+}
+
+// runMacro runs a macro as a single command for undo: its steps are saved apart and
+// then pushed as one entry, so undo reverts the whole macro. When a step fails, the
+// steps that did run can still be undone.
+func runMacro(spec *commandSpec, args []string, config *Config, db *sql.DB) error {
+	h := &config.history
+	saved := h.undo
+	h.undo = nil
+	err := spec.handler(args, config, db)
+	steps := h.undo
+	h.undo = saved
+	if len(steps) > 0 {
+		h.push(groupEntries(strings.TrimSpace(spec.names[0]+" "+strings.Join(args, " ")), steps))
+	}
+	return err
+}
+
+// groupEntries joins the entries of several commands into one. The answers before
+// are kept from the last step to the first, so the state before the first step is
+// the one restored.
+func groupEntries(command string, steps []undoEntry) undoEntry {
+	entry := undoEntry{command: command, before: steps[0].before, after: steps[len(steps)-1].after}
+	entry.before.answers, entry.after.answers = nil, nil
+	for i := range steps {
+		entry.before.answers = append(entry.before.answers, steps[len(steps)-1-i].before.answers...)
+		entry.after.answers = append(entry.after.answers, steps[i].after.answers...)
+		entry.events = append(entry.events, steps[i].events...)
+	}
+	return entry
+}
+
+func restoreSnapshot(s snapshot, config *Config, db *sql.DB) error {
+	for _, answer := range s.answers {
+		if err := restoreAnswerState(answer, config, db); err != nil {
+			return err
+		}
+	}
+	restoreCursor(s.cursor, config)
+	return nil
+}
+
+func undo(config *Config, db *sql.DB) error {
+	h := &config.history
+	if len(h.undo) == 0 {
+		return newCommandError("There is nothing to undo.")
+	}
+	entry := h.undo[len(h.undo)-1]
+	if err := restoreSnapshot(entry.before, config, db); err != nil {
+		return err
+	}
+	for _, e := range entry.events {
+		if err := deleteInterviewEvent(e.id, db); err != nil {
+			return err
+		}
+	}
+	h.undo = h.undo[:len(h.undo)-1]
+	h.redo = append(h.redo, entry)
+	printWithColorln(fmt.Sprintf("Undone: %s", entry.command), yellow, config)
+	printQuestion(config.questionIndex, config)
+	return nil
+}
+
+func redo(config *Config, db *sql.DB) error {
+	h := &config.history
+	if len(h.redo) == 0 {
+		return newCommandError("There is nothing to redo.")
+	}
+	entry := h.redo[len(h.redo)-1]
+	if err := restoreSnapshot(entry.after, config, db); err != nil {
+		return err
+	}
+	for i, e := range entry.events {
+		id, err := saveInterviewEvent(config.intervieweeID, e.event, e.detail, db)
+		if err != nil {
+			return err
+		}
+		entry.events[i].id = id
+	}
+	h.redo = h.redo[:len(h.redo)-1]
+	h.undo = append(h.undo, entry)
+	printWithColorln(fmt.Sprintf("Redone: %s", entry.command), yellow, config)
+	printQuestion(config.questionIndex, config)
+	return nil
+}
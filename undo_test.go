@@ -0,0 +1,137 @@
+package main
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func undoTestConfig() Config {
+	config := NewConfig()
+	config.hasStarted = true
+	config.selectedTopic = "java"
+	config.interview.Topics["java"] = []Question{
+		{ID: 1, Level: AssociateOrProgrammer}, {ID: 2, Level: AssociateOrProgrammer},
+		{ID: 3, Level: ProgrammerAnalyst}, {ID: 4, Level: SrProgrammer},
+	}
+	return config
+}
+
+func runTyped(t *testing.T, text string, config *Config) {
+	t.Helper()
+	spec, args, err := commands.parse(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := runCommand(spec, args, config, nil); err != nil {
+		t.Fatalf("%s: %v", text, err)
+	}
+}
+
+func Test_undo_navigation(t *testing.T) {
+	config := undoTestConfig()
+
+	runTyped(t, "next", &config)
+	runTyped(t, "+", &config)
+	runTyped(t, "goto 4", &config)
+	if q, _ := currentQuestion(&config); q.ID != 4 {
+		t.Fatalf("got question=[%d], want=[4]", q.ID)
+	}
+
+	runTyped(t, "undo", &config)
+	if q, _ := currentQuestion(&config); q.ID != 3 || config.levelIndex != 1 {
+		t.Errorf("got question=[%d] level=[%d], want question=[3] level=[1]", q.ID, config.levelIndex)
+	}
+	runTyped(t, "undo", &config)
+	runTyped(t, "undo", &config)
+	if q, _ := currentQuestion(&config); q.ID != 1 || config.levelIndex != 0 {
+		t.Errorf("got question=[%d] level=[%d], want question=[1] level=[0]", q.ID, config.levelIndex)
+	}
+	if err := undo(&config, nil); err == nil {
+		t.Errorf("there is nothing to undo, want error")
+	}
+
+	runTyped(t, "redo", &config)
+	if q, _ := currentQuestion(&config); q.ID != 2 {
+		t.Errorf("got question=[%d], want=[2]", q.ID)
+	}
+
+	runTyped(t, "prev", &config)
+	if len(config.history.redo) != 0 {
+		t.Errorf("a new command should clear the redo stack, got=[%d] entries", len(config.history.redo))
+	}
+	if err := redo(&config, nil); err == nil {
+		t.Errorf("there is nothing to redo, want error")
+	}
+}
+
+func Test_undo_notStarted(t *testing.T) {
+	config := undoTestConfig()
+	config.hasStarted = false
+
+	runTyped(t, "+", &config)
+	if len(config.history.undo) != 0 {
+		t.Errorf("got=[%d] entries, nothing is undone before the interview starts", len(config.history.undo))
+	}
+}
+
+func Test_undoHistory_push(t *testing.T) {
+	var h undoHistory
+	for i := 0; i < maxUndoEntries+5; i++ {
+		h.push(undoEntry{command: "next"})
+	}
+	if len(h.undo) != maxUndoEntries {
+		t.Errorf("got=[%d] entries, want=[%d]", len(h.undo), maxUndoEntries)
+	}
+}
+
+func Test_runUndoable_events(t *testing.T) {
+	config := undoTestConfig()
+	spec := &commandSpec{names: []string{"+"}, undo: undoCursor,
+		handler: func(args []string, config *Config, db *sql.DB) error {
+			config.history.events = append(config.history.events, savedEvent{id: 9, event: levelChangeEvent})
+			return nil
+		}}
+	if err := runCommand(spec, []string{}, &config, nil); err != nil {
+		t.Fatal(err)
+	}
+	entry := config.history.undo[0]
+	if len(entry.events) != 1 || entry.events[0].id != 9 || len(config.history.events) != 0 {
+		t.Errorf("the event should belong to the entry, got=[%+v] pending=[%+v]", entry.events, config.history.events)
+	}
+}
+
+func Test_runMacro_singleEntry(t *testing.T) {
+	config := undoTestConfig()
+	spec := &commandSpec{names: []string{"fwd"}, macro: true, handler: macroHandler([]string{"next", "+", "next"})}
+	if err := runCommand(spec, []string{}, &config, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(config.history.undo) != 1 || config.history.undo[0].command != "fwd" {
+		t.Fatalf("the macro should be a single entry, got=[%+v]", config.history.undo)
+	}
+
+	runTyped(t, "undo", &config)
+	if q, _ := currentQuestion(&config); q.ID != 1 || config.levelIndex != 0 {
+		t.Errorf("got question=[%d] level=[%d], want question=[1] level=[0]", q.ID, config.levelIndex)
+	}
+}
+
+func Test_groupEntries(t *testing.T) {
+	first := &answerState{questionID: 1, result: NotAnsweredYet}
+	second := &answerState{questionID: 1, result: OK}
+	third := &answerState{questionID: 1, result: Wrong}
+	steps := []undoEntry{
+		{before: snapshot{answers: []*answerState{first}}, after: snapshot{answers: []*answerState{second}}, events: []savedEvent{{id: 1}}},
+		{before: snapshot{answers: []*answerState{second}}, after: snapshot{answers: []*answerState{third}}, events: []savedEvent{{id: 2}}},
+	}
+	entry := groupEntries("mark", steps)
+	if n := len(entry.before.answers); n != 2 || entry.before.answers[n-1] != first {
+		t.Errorf("the state before the first step should be restored last, got=[%+v]", entry.before.answers)
+	}
+	if n := len(entry.after.answers); n != 2 || entry.after.answers[n-1] != third {
+		t.Errorf("the state after the last step should be restored last, got=[%+v]", entry.after.answers)
+	}
+	if len(entry.events) != 2 {
+		t.Errorf("got events=[%+v]", entry.events)
+	}
+}
@@ -3,15 +3,14 @@ package main
 import (
 	"bufio"
 	"database/sql"
-	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"strconv"
 	"strings"
+	"unicode"
 
-	"github.com/muesli/termenv"
 	"github.com/spf13/viper"
 )
 
@@ -19,136 +18,55 @@ func sanitizeUserInput(input string) string {
 	return strings.TrimSpace(input)
 }
 
-// Transforms user's input to a Command
-func userInputToCmd(input string) (Command, []string) {
-	if len(input) == 0 {
-		return noCmd, []string{}
-	}
-	fullCommand := words(input)
-	input = fullCommand[0]
-	input = sanitizeUserInput(input)
-	input = strings.ToLower(input)
-	switch input {
-	case "exit", "quit", ":q", "/q", "q":
-		return exitCmd, []string{}
-	case "topics", "tps", "t", "/t", ":t":
-		return topicsCmd, []string{}
-	case "help", ":h", "/h", "--h", "-h", "h":
-		return helpCmd, []string{}
-	case "use", "u", "/u", ":u", "-u", "--u", "set":
-		if len(fullCommand) <= 1 {
-			return noCmd, []string{}
-		}
-		return useCmd, fullCommand[1:]
-	case "cls", "clear":
-		return clearScreenCmd, []string{}
-	case "pwd":
-		return pwdCmd, []string{}
-	case "start", "begin":
-		return startCmd, []string{}
-	case "p", "print", "print()", "p()":
-		return printCmd, []string{}
-	case "next", "nxt", ">":
-		return nextQuestionCmd, []string{}
-	case "previous", "prev", "<":
-		return previousQuestionCmd, []string{}
-	case "view", "v":
-		return viewCmd, []string{}
-	case "y", "right", "ok", "yes", "si":
-		return rightAnswerCmd, []string{}
-	case "n", "no", "mal", "wrong", "nop", "bad", "nel":
-		return wrongAnswerCmd, []string{}
-	case "hmm", "meh", "?":
-		return mehAnswerCmd, []string{}
-	case "finish", "done", "bye":
-		return finishCmd, []string{}
-	case "+":
-		return increaseLevelCmd, []string{}
-	case "-":
-		return decreaseLevelCmd, []string{}
-	case "=":
-		return ignoreLevelCmd, []string{}
-	case "lvl":
-		return showLevelCmd, []string{}
-	case "stats":
-		return showStatsCmd, []string{}
-	case "ap":
-		return setAssociateProgrammerLevelCmd, []string{}
-	case "pa":
-		return setProgrammerAnalystLevelCmd, []string{}
-	case "sr":
-		return setSRProgrammerLevelCmd, []string{}
-	case "count", "cnt", "c":
-		return countCmd, []string{}
-	case "cmt", "comment", "note", "nt":
-		return createCommentCmd, []string{}
-	case "cq":
-		return createQuestionCmd, []string{}
-	case "va":
-		return viewCurrentQuestionAnwswerCmd, []string{}
-	case "vas":
-		return viewAnswersCmd, []string{}
-	case "li":
-		return listCandidatesCmd, []string{}
-	case "ei":
-		return exploreInterviewCmd, []string{}
-	}
-	return noCmd, []string{}
-}
-
-func listTopics(db *sql.DB) error {
+func listTopics(config *Config, db *sql.DB) error {
 	topics, err := getTopics(db)
 	if err != nil {
 		return err
 	}
 
 	for _, topic := range topics {
-		fmt.Println(termenv.String(topic.Topic).Underline().Bold())
+		if len(topic.Description) == 0 {
+			fmt.Println(style(config, topic.Topic).Underline().Bold())
+			continue
+		}
+		fmt.Printf("%s  %s\n", style(config, topic.Topic).Underline().Bold(), style(config, topic.Description).Faint())
 	}
 	return nil
 }
 
-func printHelp() {
-	usage := `
-commands:
-
-	exit|quit|:q|/q|q 			exits from this application.
-	topics|tps|t|/t|:t 			list current available topics from the DB
-	help|:h|/h|--h|-h 			shows this message.
-	use|u|/u|:u|-u|--u|set 			sets an available topic.
-	cls|clear 				clears the screen.
-	pwd 					prints the current selected topic.
-	start|begin 				starts the interview.
-	print|print()|p|p() 			prints the current question.
-	next|nxt|> 				moves to the next question.
-	previous|prev|< 			moves to the previous question.
-	view|v					prints the current available questions by level.
-	va					view answer from current question
-	vas 					view answers from candidate
-	no|n|mal|wrong|nop|bad|nel 		marks a question as wrong.
-	ok|yes|si|right|y			marks a question as right / OK.
-	hmm|meh|?				marks a question as neutral.
-	finish|done|bye				finishes an interview.
-	cq					create a question and save it to the database.
-	+					increases the level of the interview, it could be from Programmer Analyst to a 
-					Sr Programmer Analyst as an example.
-	- 					decreases the level of the interview.
-	= 					ignore levels.
-	lvl					prints the current interview level.
-	stats					shows some stats and the current configuration for the interview.
-	ap					sets the level of the interview to "Associate Programmer"
-	pa					sets the level of the interview to "Programmer Analyst"
-	sr					sets the level of the interview to "Sr Programmer Analyst"
-
-
-	Any other command or sentence that is not listed here will be simply ignored.
-	`
-
-	fmt.Println(usage)
-}
-
+// words splits the input on white space, quoted text is kept as a single word so
+// values with spaces can be passed inline, e.g. cq 1 2 "What is a JVM?" "..."
 func words(input string) []string {
-	return strings.Fields(input)
+	fields := make([]string, 0)
+	var b strings.Builder
+	var quote rune
+	inWord := false
+	for _, r := range input {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				b.WriteRune(r)
+			}
+		case (r == '"' || r == '\'') && !inWord:
+			quote = r
+			inWord = true
+		case unicode.IsSpace(r):
+			if inWord {
+				fields = append(fields, b.String())
+				b.Reset()
+				inWord = false
+			}
+		default:
+			b.WriteRune(r)
+			inWord = true
+		}
+	}
+	if inWord {
+		fields = append(fields, b.String())
+	}
+	return fields
 }
 
 func clearScreen() {
@@ -191,8 +109,7 @@ func setTopic(options []string, config *Config, db *sql.DB) error {
 		}
 		config.interview.Topics[config.selectedTopic] = questionsPerTopic
 	} else {
-		fmt.Println(
-			termenv.String(fmt.Sprintf("topic '%s' not found or the topic selected doesn't have questions.", topicName)).Foreground(config.colorProfile.Color(red)))
+		return newCommandError("topic '%s' not found or the topic selected doesn't have questions.", topicName)
 	}
 	return nil
 }
@@ -203,9 +120,15 @@ func loadQuestionsFromTopic(config *Config, db *sql.DB) ([]Question, error) {
 	if err != nil {
 		return []Question{}, err
 	}
+	if config.shuffle {
+		questionsPerTopic = sampleQuestions(questionsPerTopic, config.selectedTopic, config.seed, config.drawCount)
+	}
 
 	levelFound := findLevel(&questionsPerTopic, AssociateOrProgrammer, ProgrammerAnalyst, SrProgrammer)
 	fmt.Printf("Loaded -> '%d' questions, starting with: %s level.\n", len(questionsPerTopic), levelFound)
+	if config.shuffle {
+		printWithColorln("Questions "+samplingDescription(config)+".", cyan, config)
+	}
 
 	levelQCounts := levelQuestionCounts(&questionsPerTopic)
 	fmt.Printf("Associate Programmer = ")
@@ -236,13 +159,19 @@ func shortIntervieweeName(name string, min int) string {
 	return fmt.Sprintf("(%s...)", name[0:min])
 }
 
-func ps1String(ps1, selectedTopic, intervieweeName string) string {
+func ps1String(config *Config, ps1, selectedTopic, intervieweeName, timeLeft string) string {
 	if selectedTopic == "" {
-		return "$ "
+		return ps1
+	}
+	if len(timeLeft) > 0 {
+		return fmt.Sprintf(
+			"/%s %s [%s] %s",
+			style(config, selectedTopic).Faint(), shortIntervieweeName(intervieweeName, minNumberOfCharsInIntervieweeName),
+			style(config, timeLeft).Faint(), ps1)
 	}
 	return fmt.Sprintf(
-		"/%s %s $ ",
-		termenv.String(selectedTopic).Faint(), shortIntervieweeName(intervieweeName, minNumberOfCharsInIntervieweeName))
+		"/%s %s %s",
+		style(config, selectedTopic).Faint(), shortIntervieweeName(intervieweeName, minNumberOfCharsInIntervieweeName), ps1)
 }
 
 func (q Question) String() string {
@@ -285,8 +214,8 @@ func viewQuestions(config *Config) {
 		fmt.Println()
 		return
 	}
-	for _, q := range config.interview.Topics[config.selectedTopic] {
-		fmt.Println(q.StringNoResult())
+	for i, q := range config.interview.Topics[config.selectedTopic] {
+		fmt.Printf("#%d %s\n", i+1, q.StringNoResult())
 	}
 }
 
@@ -298,7 +227,7 @@ func viewQuestionsByLevel(config *Config) {
 	currentLevel := config.levels[config.levelIndex]
 	currentLevelQuestions := getQuestionsFromLevel(currentLevel, config)
 	for _, q := range currentLevelQuestions {
-		fmt.Println(q.StringNoResult())
+		fmt.Printf("#%d %s\n", questionPosition(q.ID, config), q.StringNoResult())
 	}
 }
 
@@ -313,11 +242,11 @@ func readIntervieweeName(stdin io.Reader) (string, bool) {
 }
 
 func printWithColorln(msg, colorCode string, config *Config) {
-	fmt.Println(termenv.String(msg).Foreground(config.colorProfile.Color(colorCode)))
+	fmt.Println(style(config, msg).Foreground(color(config, colorCode)))
 }
 
 func printWithColorf(config *Config, msg, colorCode string, a ...interface{}) {
-	fmt.Printf(termenv.String(msg).Foreground(config.colorProfile.Color(colorCode)).String(), a...)
+	fmt.Printf(style(config, msg).Foreground(color(config, colorCode)).String(), a...)
 }
 
 func resetStatus(config *Config) {
@@ -336,19 +265,16 @@ func showLevel(config *Config) {
 
 func setAnswerAsNeutral(config *Config, db *sql.DB) error {
 	questions := config.interview.Topics[config.selectedTopic]
+	if config.questionIndex >= len(questions) {
+		return newCommandError("There are no questions for this topic.")
+	}
 	q := questions[config.questionIndex]
 	q.Result = Neutral
 
 	if err := saveAnswer(&q, Neutral, config, db); err != nil {
 		return err
 	}
-
-	currentLevel := config.levels[config.levelIndex]
-	currentLevelQuestions := getQuestionsFromLevel(currentLevel, config)
-	index := config.individualLevelIndexes[int(currentLevel)-1]
-	id := currentLevelQuestions[index].ID
-	qs := config.interview.Topics[config.selectedTopic]
-	markQuestionAs(id, Neutral, &qs)
+	markQuestionAs(q.ID, Neutral, &questions)
 
 	printWithColorln(fmt.Sprintf("Answer has saved as '%s'", Neutral), magenta, config)
 	return nil
@@ -356,19 +282,16 @@ func setAnswerAsNeutral(config *Config, db *sql.DB) error {
 
 func setAnswerAsOK(config *Config, db *sql.DB) error {
 	questions := config.interview.Topics[config.selectedTopic]
+	if config.questionIndex >= len(questions) {
+		return newCommandError("There are no questions for this topic.")
+	}
 	q := questions[config.questionIndex]
 	q.Result = OK
 
 	if err := saveAnswer(&q, OK, config, db); err != nil {
 		return err
 	}
-
-	currentLevel := config.levels[config.levelIndex]
-	currentLevelQuestions := getQuestionsFromLevel(currentLevel, config)
-	index := config.individualLevelIndexes[int(currentLevel)-1]
-	id := currentLevelQuestions[index].ID
-	qs := config.interview.Topics[config.selectedTopic]
-	markQuestionAs(id, OK, &qs)
+	markQuestionAs(q.ID, OK, &questions)
 
 	printWithColorln(fmt.Sprintf("Answer has saved as '%s'", OK), green, config)
 	return nil
@@ -376,19 +299,16 @@ func setAnswerAsOK(config *Config, db *sql.DB) error {
 
 func setAnswerAsWrong(config *Config, db *sql.DB) error {
 	questions := config.interview.Topics[config.selectedTopic]
+	if config.questionIndex >= len(questions) {
+		return newCommandError("There are no questions for this topic.")
+	}
 	q := questions[config.questionIndex]
 	q.Result = Wrong
 
 	if err := saveAnswer(&q, Wrong, config, db); err != nil {
 		return err
 	}
-
-	currentLevel := config.levels[config.levelIndex]
-	currentLevelQuestions := getQuestionsFromLevel(currentLevel, config)
-	index := config.individualLevelIndexes[int(currentLevel)-1]
-	id := currentLevelQuestions[index].ID
-	qs := config.interview.Topics[config.selectedTopic]
-	markQuestionAs(id, Wrong, &qs)
+	markQuestionAs(q.ID, Wrong, &questions)
 
 	printWithColorln(fmt.Sprintf("Answer has saved as '%s'", Wrong), red, config)
 	return nil
@@ -398,6 +318,9 @@ func answerAs(config *Config, ans Result, messageColorCode string, db *sql.DB) e
 	currentLevel := config.levels[config.levelIndex]
 	currentLevelQuestions := getQuestionsFromLevel(currentLevel, config)
 	index := config.individualLevelIndexes[int(currentLevel)-1]
+	if index >= len(currentLevelQuestions) {
+		return newCommandError("There are no questions for this level.")
+	}
 	id := currentLevelQuestions[index].ID
 	q := currentLevelQuestions[index]
 	qs := config.interview.Topics[config.selectedTopic]
@@ -410,9 +333,9 @@ func answerAs(config *Config, ans Result, messageColorCode string, db *sql.DB) e
 }
 
 func markQuestionAs(id int, ans Result, qs *[]Question) {
-	for _, q := range *qs {
+	for i, q := range *qs {
 		if q.ID == id {
-			(*qs)[id-1].Result = ans
+			(*qs)[i].Result = ans
 			break
 		}
 	}
@@ -511,13 +434,17 @@ func NewConfig() Config {
 	cfg := Config{}
 	cfg.selectedTopic = ""
 	cfg.ps1 = "$ "
-	cfg.colorProfile = termenv.ColorProfile()
+	cfg.colorProfile = outputProfile()
+	cfg.theme = themes[defaultTheme]
 	cfg.interview = Interview{Topics: make(map[string][]Question)}
 	cfg.topicQuestionsLevel = AssociateOrProgrammer
 	cfg.levelIndex = 0
 	cfg.ignoreLevelChecking = false
 	cfg.individualLevelIndexes = []int{0, 0, 0}
 	cfg.questionIndex = 0
+	cfg.adaptive = defaultAdaptiveRules
+	cfg.timebox = newTimebox()
+	cfg.scoring = defaultScoreWeights
 	cfg.levels = [3]Level{
 		AssociateOrProgrammer, ProgrammerAnalyst, SrProgrammer,
 	}
@@ -536,75 +463,52 @@ func readConfig(filename, configPath string, defaults map[string]interface{}) (*
 	return v, err
 }
 
-func readComment() (string, error) {
-	scanner := bufio.NewScanner(os.Stdin)
-	var b strings.Builder
-	for scanner.Scan() {
-		b.WriteString(scanner.Text())
-	}
-
-	if err := scanner.Err(); err != nil {
-		return "", err
-	}
-
-	return b.String(), nil
-}
-
-func makeQuestion(config *Config, db *sql.DB) error {
+func makeQuestion(options []string, config *Config, db *sql.DB) error {
 	topics, err := getTopics(db)
 	if err != nil {
 		return err
 	}
 
-	for idx, topic := range topics {
-		printWithColorf(config, "%d: %s\n", blue, idx, topic.Topic)
+	if len(options) == 0 {
+		for idx, topic := range topics {
+			printWithColorf(config, "%d: %s\n", blue, idx, topic.Topic)
+		}
+		fmt.Println()
 	}
-	fmt.Println()
-	fmt.Printf("Topic? ")
 
-	reader := bufio.NewReader(os.Stdin)
-	userInput, err := reader.ReadString('\n')
+	userInput, err := argOrAsk(options, 0, "Topic? ", config)
 	if err != nil {
 		return err
 	}
 
 	userInput = strings.TrimSpace(userInput)
 	topicIndex, err := strconv.Atoi(userInput)
-	if err != nil {
-		return err
+	if err != nil || topicIndex < 0 || topicIndex >= len(topics) {
+		return newCommandError("invalid topic index '%s', it must be between 0 and %d", userInput, len(topics)-1)
 	}
 
-	if topicIndex < 0 || topicIndex > len(topics) {
-		return errors.New("invalid topic index")
+	if len(options) == 0 {
+		printWithColorf(config, "\n1) Programmer\n2) Programmer Analyst\n3) Sr. Programmer Analyst ", blue)
+		fmt.Println()
 	}
-
-	printWithColorf(config, "\n1) Programmer\n2) Programmer Analyst\n3) Sr. Programmer Analyst ", blue)
-	fmt.Println()
-	fmt.Printf("Level? ")
-	userInput, err = reader.ReadString('\n')
+	userInput, err = argOrAsk(options, 1, "Level? ", config)
 	if err != nil {
 		return err
 	}
 	userInput = strings.TrimSpace(userInput)
 
 	levelIndex, err := strconv.Atoi(userInput)
-	if err != nil {
-		return err
-	}
-	if levelIndex < 0 || levelIndex > 3 {
-		return errors.New("invalid level index")
+	if err != nil || levelIndex < int(AssociateOrProgrammer) || levelIndex > int(SrProgrammer) {
+		return newCommandError("invalid level '%s', it must be 1, 2 or 3", userInput)
 	}
 
-	fmt.Printf("Question? ")
-	userInput, err = reader.ReadString('\n')
+	userInput, err = argOrAsk(options, 2, "Question? ", config)
 	if err != nil {
 		return err
 	}
 	question := strings.TrimSpace(userInput)
 
-	fmt.Println()
-	fmt.Printf("Answer? ")
-	userInput, err = reader.ReadString('\n')
+	userInput, err = argOrAsk(options, 3, "Answer? ", config)
 	if err != nil {
 		return err
 	}
@@ -612,7 +516,7 @@ func makeQuestion(config *Config, db *sql.DB) error {
 
 	q := Question{Q: question, Level: Level(levelIndex), Result: NotAnsweredYet}
 
-	if err = saveQuestion(&q, topicIndex, answer, db); err != nil {
+	if err = saveQuestion(&q, topics[topicIndex].ID, answer, db); err != nil {
 		return err
 	}
 
@@ -641,56 +545,34 @@ func viewAnswer(questionIndex int, config *Config) {
 }
 
 func listAnswers(candidateID int, config *Config, db *sql.DB) error {
-	answers, err := getAnswersFromCandidate(candidateID, db)
-	if err != nil {
-		return err
-	}
-	for _, ans := range answers {
-		fmt.Println(ans)
-	}
-	return nil
-}
-
-func exploreInterview(config *Config, db *sql.DB) error {
-	candidates, err := getCandidates(db)
+	candidate, err := getCandidate(candidateID, db)
 	if err != nil {
 		return err
 	}
-	if len(candidates) == 0 {
-		printWithColorln("There are no interviews available to explore", yellow, config)
-		return nil
-	}
-	for _, candidate := range candidates {
-		printWithColorf(config, fmt.Sprintf(` %d) "%s"  (%s)`, candidate.ID, candidate.Name, candidate.Date), green)
-		fmt.Println()
-	}
-
-	fmt.Println()
-	fmt.Printf("Candidate # to explore? ")
-
-	reader := bufio.NewReader(os.Stdin)
-	userInput, err := reader.ReadString('\n')
-	if err != nil {
-		return err
+	if candidate.QuestionSeed.Valid {
+		printWithColorln(fmt.Sprintf("Questions shuffled, reproduce them with: %s",
+			reproduceCommands(candidate.QuestionSeed.Int64, int(candidate.QuestionDraw.Int64))), cyan, config)
 	}
 
-	userInput = strings.TrimSpace(userInput)
-	candidateID, err := strconv.Atoi(userInput)
+	answers, err := getAnswersFromCandidate(candidateID, db)
 	if err != nil {
 		return err
 	}
-	if valid := validateCandidateID(candidateID, &candidates); !valid {
-		return fmt.Errorf("%d not valid", candidateID)
+	for _, ans := range answers {
+		fmt.Println(ans)
 	}
 
-	answers, err := getAnswersFromCandidate(candidateID, db)
+	events, err := getInterviewEvents(candidateID, db)
 	if err != nil {
 		return err
 	}
-	for _, ans := range answers {
-		fmt.Println(ans)
+	if len(events) > 0 {
+		fmt.Println()
+		printWithColorln("History:", cyan, config)
+		for _, event := range events {
+			fmt.Println(event)
+		}
 	}
-
 	return nil
 }
 
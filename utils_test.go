@@ -26,48 +26,52 @@ func Test_sanitizeUserInput(t *testing.T) {
 	}
 }
 
-func Test_userInputToCmd(t *testing.T) {
+func Test_userInputToCommand(t *testing.T) {
 	type test struct {
 		input string
-		want  Command
+		want  string
 	}
 
 	tests := []test{
-		{input: ":_", want: noCmd},
-		{input: ":q", want: exitCmd},
-		{input: "exit", want: exitCmd},
-		{input: "use golang", want: useCmd},
-		{input: "cls", want: clearScreenCmd},
-		{input: "pwd", want: pwdCmd},
-		{input: "start", want: startCmd},
-		{input: "print", want: printCmd},
-		{input: ">", want: nextQuestionCmd},
-		{input: "<", want: previousQuestionCmd},
-		{input: "view", want: viewCmd},
-		{input: "ok", want: rightAnswerCmd},
-		{input: "no", want: wrongAnswerCmd},
-		{input: "meh", want: mehAnswerCmd},
-		{input: "finish", want: finishCmd},
-		{input: "topics", want: topicsCmd},
-		{input: "help", want: helpCmd},
-		{input: "+", want: increaseLevelCmd},
-		{input: "-", want: decreaseLevelCmd},
-		{input: "=", want: ignoreLevelCmd},
-		{input: "lvl", want: showLevelCmd},
-		{input: "stats", want: showStatsCmd},
-		{input: "cmt", want: createCommentCmd},
-		{input: "cq", want: createQuestionCmd},
-		{input: "ap", want: setAssociateProgrammerLevelCmd},
-		{input: "pa", want: setProgrammerAnalystLevelCmd},
-		{input: "sr", want: setSRProgrammerLevelCmd},
-		{input: "use", want: noCmd},
-		{input: "c", want: countCmd},
-		{input: "", want: noCmd},
+		{input: ":_", want: ""},
+		{input: ":q", want: "exit"},
+		{input: "exit", want: "exit"},
+		{input: "use golang", want: "use"},
+		{input: "cls", want: "cls"},
+		{input: "pwd", want: "pwd"},
+		{input: "start", want: "start"},
+		{input: "print", want: "print"},
+		{input: ">", want: "next"},
+		{input: "<", want: "previous"},
+		{input: "view", want: "view"},
+		{input: "ok", want: "ok"},
+		{input: "no", want: "no"},
+		{input: "meh", want: "hmm"},
+		{input: "finish", want: "finish"},
+		{input: "topics", want: "topics"},
+		{input: "help", want: "help"},
+		{input: "+", want: "+"},
+		{input: "-", want: "-"},
+		{input: "=", want: "="},
+		{input: "lvl", want: "lvl"},
+		{input: "stats", want: "stats"},
+		{input: "cmt", want: "cmt"},
+		{input: "cq", want: "cq"},
+		{input: "ap", want: "ap"},
+		{input: "pa", want: "pa"},
+		{input: "sr", want: "sr"},
+		{input: "use", want: ""},
+		{input: "c", want: "count"},
+		{input: "", want: ""},
 	}
 
 	for _, tc := range tests {
-		if got, _ := userInputToCmd(tc.input); got != tc.want {
-			t.Errorf("got=[%s], want=[%s]", got, tc.want)
+		got := ""
+		if spec, _, err := commands.parse(tc.input); err == nil {
+			got = spec.name()
+		}
+		if got != tc.want {
+			t.Errorf("input=[%s], got=[%s], want=[%s]", tc.input, got, tc.want)
 		}
 	}
 }
@@ -151,6 +155,7 @@ func Test_ps1String(t *testing.T) {
 		ps1             string
 		selectedTopic   string
 		intervieweeName string
+		timeLeft        string
 	}
 	tests := []struct {
 		name string
@@ -167,14 +172,26 @@ func Test_ps1String(t *testing.T) {
 			args: args{ps1: "$ ", selectedTopic: "", intervieweeName: "leo"},
 			want: "2420",
 		},
+		{
+			name: "timeboxed",
+			args: args{ps1: "$ ", selectedTopic: "linux", intervieweeName: "leo", timeLeft: "12:05 left"},
+			want: "2f1b5b326d6c696e75781b5b306d20286c656f29205b1b5b326d31323a3035206c6566741b5b306d5d202420",
+		},
 	}
+	config := NewConfig()
+	config.colorProfile = termenv.TrueColor
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := fmt.Sprintf("%x", ps1String(tt.args.ps1, tt.args.selectedTopic, tt.args.intervieweeName)); got != tt.want {
+			if got := fmt.Sprintf("%x", ps1String(&config, tt.args.ps1, tt.args.selectedTopic, tt.args.intervieweeName, tt.args.timeLeft)); got != tt.want {
 				t.Errorf("ps1String() = [%v], want [%v]", got, tt.want)
 			}
 		})
 	}
+
+	config.colorProfile = termenv.Ascii
+	if got := ps1String(&config, "$ ", "linux", "leo", "12:05 left"); got != "/linux (leo) [12:05 left] $ " {
+		t.Errorf("colors are off, got=[%q]", got)
+	}
 }
 
 func Test_setLevel(t *testing.T) {
@@ -332,6 +349,10 @@ func Test_markQuestionAs(t *testing.T) {
 	}
 
 	tests := []test{
+		{id: 31, ans: OK, qs: []Question{
+			Question{ID: 30, Result: NotAnsweredYet},
+			Question{ID: 31, Result: NotAnsweredYet},
+		}},
 		{id: 1, ans: Wrong, qs: []Question{
 			Question{ID: 0, Result: NotAnsweredYet},
 			Question{ID: 1, Result: NotAnsweredYet},
@@ -343,8 +364,13 @@ func Test_markQuestionAs(t *testing.T) {
 
 	for _, tt := range tests {
 		markQuestionAs(tt.id, tt.ans, &tt.qs)
-		if tt.qs[tt.id-1].Result != tt.ans {
-			t.Errorf("want=[%s], got=[%s]", tt.ans, tt.qs[tt.id].Answer)
+		for _, q := range tt.qs {
+			if q.ID == tt.id && q.Result != tt.ans {
+				t.Errorf("want=[%s], got=[%s]", tt.ans, q.Result)
+			}
+			if q.ID != tt.id && q.Result != NotAnsweredYet {
+				t.Errorf("Q%d should not have been marked, got=[%s]", q.ID, q.Result)
+			}
 		}
 	}
 }
@@ -395,3 +421,24 @@ func TestNewConfig(t *testing.T) {
 		t.Errorf("got=[%s], want=[%s]", config.levels, expectedLevels)
 	}
 }
+
+func Test_words(t *testing.T) {
+	type test struct {
+		input string
+		want  []string
+	}
+
+	tests := []test{
+		{input: "use  java", want: []string{"use", "java"}},
+		{input: `cq 1 2 "What is a JVM?" 'a virtual machine'`, want: []string{"cq", "1", "2", "What is a JVM?", "a virtual machine"}},
+		{input: "cmt he didn't know", want: []string{"cmt", "he", "didn't", "know"}},
+		{input: `cmt ""`, want: []string{"cmt", ""}},
+		{input: "", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		if got := words(tt.input); !EqualTopics(got, tt.want) {
+			t.Errorf("got=[%q], want=[%q]", got, tt.want)
+		}
+	}
+}